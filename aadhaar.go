@@ -0,0 +1,208 @@
+// FilePath: aadhaar.go
+
+package verhoeff
+
+import (
+    "crypto/subtle"
+    "fmt"
+    "strconv"
+    "unicode"
+)
+
+// aadhaarLength is the number of digits in an Aadhaar number, including the
+// checksum digit.
+const aadhaarLength = 12
+
+// AadhaarReport describes the structural problems found in an Aadhaar-like
+// number.
+type AadhaarReport struct {
+    // LengthOK is true if the input is exactly 12 characters long.
+    LengthOK bool
+    // AllDigits is true if every character is a digit.
+    AllDigits bool
+    // ChecksumOK is true if the length and characters are valid and the
+    // last digit is the correct checksum.
+    ChecksumOK bool
+    // ExpectedCheck is the checksum digit the first 11 digits call for, or
+    // -1 if it could not be calculated.
+    ExpectedCheck int
+    // FirstBadIndex is the byte offset of the first non-digit character, or
+    // -1 if there is none.
+    FirstBadIndex int
+}
+
+// InspectAadhaar checks an Aadhaar number and reports each structural issue
+// separately instead of stopping at the first one. Malformed input is
+// described in the report; an error is only returned for empty input.
+func InspectAadhaar(s string) (AadhaarReport, error) {
+    report := AadhaarReport{ExpectedCheck: -1, FirstBadIndex: -1}
+    if s == "" {
+        return report, ErrEmptyInput
+    }
+
+    report.LengthOK = len(s) == aadhaarLength
+    report.AllDigits = true
+    for i := 0; i < len(s); i++ {
+        if s[i] < '0' || s[i] > '9' {
+            report.AllDigits = false
+            report.FirstBadIndex = i
+            break
+        }
+    }
+
+    if !report.LengthOK || !report.AllDigits {
+        return report, nil
+    }
+
+    digits, err := stringToDigits(s)
+    if err != nil {
+        return report, err
+    }
+    report.ExpectedCheck = calculateChecksum(digits[:aadhaarLength-1])
+    report.ChecksumOK = digits[aadhaarLength-1] == report.ExpectedCheck
+    return report, nil
+}
+
+// AadhaarResult is the outcome of validating one number in a batch.
+type AadhaarResult struct {
+    // Valid is true if the number is a well-formed Aadhaar number with a
+    // correct checksum digit.
+    Valid bool
+    // Reason explains why the number is not valid, or is empty if it is.
+    Reason string
+}
+
+// ValidateAadhaarBatch validates every number with ValidateAadhaar and, in
+// the same pass, groups the indices of numbers that appear more than once.
+// Each group lists the indices of identical strings in ascending order, and
+// groups are ordered by their first index. Numbers are compared exactly as
+// given, valid or not.
+//
+// Problems with individual numbers are reported in results; err is reserved
+// for failures of the batch as a whole and is currently always nil.
+func ValidateAadhaarBatch(numbers []string) (results []AadhaarResult, duplicates [][]int, err error) {
+    results = make([]AadhaarResult, len(numbers))
+    groups := map[string]int{}
+    duplicates = [][]int{}
+    var seen [][]int
+
+    for i, number := range numbers {
+        valid, err := ValidateAadhaar(number)
+        switch {
+        case err != nil:
+            results[i].Reason = err.Error()
+        case !valid:
+            results[i].Reason = ErrChecksumMismatch.Error()
+        default:
+            results[i].Valid = true
+        }
+
+        group, ok := groups[number]
+        if !ok {
+            groups[number] = len(seen)
+            seen = append(seen, []int{i})
+            continue
+        }
+        seen[group] = append(seen[group], i)
+    }
+
+    for _, indices := range seen {
+        if len(indices) > 1 {
+            duplicates = append(duplicates, indices)
+        }
+    }
+    return results, duplicates, nil
+}
+
+// vidLength is the number of digits in an Aadhaar Virtual ID, including the
+// checksum digit.
+const vidLength = 16
+
+// ValidateVID checks if an Aadhaar Virtual ID is valid. VIDs must be exactly
+// 16 digits, and the last digit is the checksum over the first 15.
+func ValidateVID(s string) (bool, error) {
+    if len(s) != vidLength {
+        return false, fmt.Errorf("virtual IDs should be 16 digits in length: %w",
+            ErrInvalidLength)
+    }
+
+    digits, err := stringToDigits(s)
+    if err != nil {
+        return false, fmt.Errorf("virtual IDs must contain only numbers: %w", err)
+    }
+    return validateChecksum(digits), nil
+}
+
+// GenerateVID calculates the checksum digit for the 15-digit payload of an
+// Aadhaar Virtual ID.
+func GenerateVID(payload string) (int, error) {
+    if len(payload) != vidLength-1 {
+        return -1, fmt.Errorf("virtual ID payloads should be 15 digits in length: %w",
+            ErrInvalidLength)
+    }
+
+    digits, err := stringToDigits(payload)
+    if err != nil {
+        return -1, fmt.Errorf("virtual IDs must contain only numbers: %w", err)
+    }
+    return calculateChecksum(digits), nil
+}
+
+// AppendVIDChecksum appends the checksum digit to a 15-digit Virtual ID
+// payload, giving the full 16-digit VID.
+func AppendVIDChecksum(payload string) (string, error) {
+    checksum, err := GenerateVID(payload)
+    if err != nil {
+        return "", err
+    }
+    return payload + strconv.Itoa(checksum), nil
+}
+
+// ValidateAadhaarFormatted validates an Aadhaar number as people write it,
+// such as "2345 6789 0124" or "2345-6789-0124", by removing ASCII spaces
+// and hyphens and then applying ValidateAadhaar, so exactly 12 digits must
+// remain. Separators may appear anywhere; letters and other punctuation are
+// still rejected, with the offset in any *InvalidCharError pointing into s
+// as given.
+func ValidateAadhaarFormatted(s string) (bool, error) {
+    // Check characters before stripping, so offsets match the input.
+    for offset, char := range s {
+        if char != ' ' && char != '-' && !unicode.IsDigit(char) {
+            return false, fmt.Errorf("aadhaar numbers must contain only numbers: %w",
+                &InvalidCharError{Char: char, Offset: offset})
+        }
+    }
+    return ValidateAadhaar(stripSeparators(s, lenientSeparators))
+}
+
+// ValidateAadhaarConstantTime checks an Aadhaar number like ValidateAadhaar,
+// but compares the supplied check digit with the expected one using
+// crypto/subtle, so the accept/reject decision does not branch on the
+// digits. This is a hardened variant for auditors who flag timing side
+// channels on sensitive identifiers.
+//
+// Only that final comparison is constant time. Inputs with the wrong length
+// or with non-digit characters are rejected early, and computing the
+// expected digit walks the table lookups for the first 11 digits, whose
+// memory access pattern depends on their values.
+func ValidateAadhaarConstantTime(s string) (bool, error) {
+    if len(s) != aadhaarLength {
+        return false, fmt.Errorf("aadhaar numbers should be 12 digits in length: %w",
+            ErrInvalidLength)
+    }
+
+    digits, err := stringToDigits(s)
+    if err != nil {
+        return false, fmt.Errorf("aadhaar numbers must contain only numbers: %w", err)
+    }
+    // The length check above counts bytes; multi-byte digits such as
+    // Devanagari ones can pass it with fewer than 12 digits.
+    if len(digits) != aadhaarLength {
+        return false, fmt.Errorf("aadhaar numbers should be 12 digits in length: %w",
+            ErrInvalidLength)
+    }
+
+    expected := calculateChecksum(digits[:aadhaarLength-1])
+    check := digits[aadhaarLength-1]
+    return subtle.ConstantTimeEq(int32(expected), int32(check)) == 1, nil
+}
@@ -0,0 +1,264 @@
+// FilePath: aadhaar_test.go
+
+package verhoeff
+
+import (
+    "errors"
+    "testing"
+)
+
+func TestInspectAadhaar(t *testing.T) {
+    tests := []struct {
+        name     string
+        input    string
+        expected AadhaarReport
+        hasError bool
+    }{
+        {
+            "Valid Aadhaar", "234567890124",
+            AadhaarReport{true, true, true, 4, -1}, false,
+        },
+        {
+            "Wrong checksum", "234567890125",
+            AadhaarReport{true, true, false, 4, -1}, false,
+        },
+        {
+            "Too short", "23456789012",
+            AadhaarReport{false, true, false, -1, -1}, false,
+        },
+        {
+            "Letter inside", "2345678a0124",
+            AadhaarReport{true, false, false, -1, 7}, false,
+        },
+        {
+            "Too long with letter", "2345a6789012499",
+            AadhaarReport{false, false, false, -1, 4}, false,
+        },
+        {
+            "Empty input", "",
+            AadhaarReport{false, false, false, -1, -1}, true,
+        },
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            got, err := InspectAadhaar(tt.input)
+
+            if (err != nil) != tt.hasError {
+                t.Errorf("InspectAadhaar() error = %v, wantErr %v",
+                    err, tt.hasError)
+                return
+            }
+
+            if got != tt.expected {
+                t.Errorf("InspectAadhaar() = %+v, want %+v",
+                    got, tt.expected)
+            }
+        })
+    }
+}
+
+func TestValidateAadhaarBatch(t *testing.T) {
+    numbers := []string{
+        "234567890124", // valid
+        "234567890125", // wrong checksum
+        "234567890124", // duplicate of 0
+        "2345678a0124", // letter
+        "234567890125", // duplicate of 1
+        "23456789012",  // too short
+        "234567890124", // duplicate of 0
+    }
+
+    results, duplicates, err := ValidateAadhaarBatch(numbers)
+    if err != nil {
+        t.Fatalf("ValidateAadhaarBatch() error = %v", err)
+    }
+
+    expectedValid := []bool{true, false, true, false, false, false, true}
+    for i, result := range results {
+        if result.Valid != expectedValid[i] {
+            t.Errorf("results[%d].Valid = %v, want %v",
+                i, result.Valid, expectedValid[i])
+        }
+        if result.Valid != (result.Reason == "") {
+            t.Errorf("results[%d] = %+v, reason must be set only when invalid",
+                i, result)
+        }
+    }
+
+    if results[1].Reason != ErrChecksumMismatch.Error() {
+        t.Errorf("results[1].Reason = %q, want %q",
+            results[1].Reason, ErrChecksumMismatch.Error())
+    }
+
+    expectedGroups := [][]int{{0, 2, 6}, {1, 4}}
+    if len(duplicates) != len(expectedGroups) {
+        t.Fatalf("duplicates = %v, want %v", duplicates, expectedGroups)
+    }
+    for g := range expectedGroups {
+        if len(duplicates[g]) != len(expectedGroups[g]) {
+            t.Fatalf("duplicates = %v, want %v", duplicates, expectedGroups)
+        }
+        for i := range expectedGroups[g] {
+            if duplicates[g][i] != expectedGroups[g][i] {
+                t.Fatalf("duplicates = %v, want %v", duplicates, expectedGroups)
+            }
+        }
+    }
+
+    t.Run("No numbers", func(t *testing.T) {
+        results, duplicates, err := ValidateAadhaarBatch(nil)
+        if err != nil || len(results) != 0 || len(duplicates) != 0 {
+            t.Errorf("ValidateAadhaarBatch(nil) = %v, %v, %v",
+                results, duplicates, err)
+        }
+    })
+}
+
+func TestValidateVID(t *testing.T) {
+    tests := []struct {
+        name     string
+        input    string
+        expected bool
+        hasError bool
+    }{
+        {"Valid VID", "9123456789012346", true, false},
+        {"Leading zeros", "0000000000000002", true, false},
+        {"Wrong checksum", "9123456789012345", false, false},
+        {"Aadhaar length", "234567890124", false, true},
+        {"Too long", "91234567890123460", false, true},
+        {"Non-digit", "912345678901234a", false, true},
+        {"Empty input", "", false, true},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            got, err := ValidateVID(tt.input)
+
+            if (err != nil) != tt.hasError {
+                t.Errorf("ValidateVID() error = %v, wantErr %v",
+                    err, tt.hasError)
+                return
+            }
+
+            if got != tt.expected {
+                t.Errorf("ValidateVID() = %v, want %v", got, tt.expected)
+            }
+        })
+    }
+}
+
+func TestAppendVIDChecksum(t *testing.T) {
+    tests := []struct {
+        name     string
+        input    string
+        expected string
+        hasError bool
+    }{
+        {"Valid payload", "912345678901234", "9123456789012346", false},
+        {"Full VID", "9123456789012346", "", true},
+        {"Too short", "91234567890123", "", true},
+        {"Non-digit", "91234567890123a", "", true},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            got, err := AppendVIDChecksum(tt.input)
+
+            if (err != nil) != tt.hasError {
+                t.Errorf("AppendVIDChecksum() error = %v, wantErr %v",
+                    err, tt.hasError)
+                return
+            }
+
+            if got != tt.expected {
+                t.Errorf("AppendVIDChecksum() = %v, want %v", got, tt.expected)
+            }
+
+            if tt.hasError {
+                return
+            }
+
+            if digit, _ := GenerateVID(tt.input); digit != int(got[len(got)-1]-'0') {
+                t.Errorf("GenerateVID() = %d, want %c", digit, got[len(got)-1])
+            }
+        })
+    }
+}
+
+func TestValidateAadhaarConstantTime(t *testing.T) {
+    inputs := []string{
+        "234567890124",
+        "234567890125",
+        "123456789010",
+        "2345678a0124",
+        "23456789012",
+        "",
+    }
+
+    for _, input := range inputs {
+        t.Run(input, func(t *testing.T) {
+            want, wantErr := ValidateAadhaar(input)
+            got, err := ValidateAadhaarConstantTime(input)
+
+            if (err != nil) != (wantErr != nil) {
+                t.Errorf("ValidateAadhaarConstantTime() error = %v, ValidateAadhaar() error = %v",
+                    err, wantErr)
+                return
+            }
+
+            if got != want {
+                t.Errorf("ValidateAadhaarConstantTime() = %v, want %v", got, want)
+            }
+        })
+    }
+
+    // Four Devanagari digits fill 12 bytes but are only 4 digits.
+    if _, err := ValidateAadhaarConstantTime("१२३४"); !errors.Is(err, ErrInvalidLength) {
+        t.Errorf("ValidateAadhaarConstantTime(\"१२३४\") error = %v, want ErrInvalidLength", err)
+    }
+}
+
+func TestValidateAadhaarFormatted(t *testing.T) {
+    tests := []struct {
+        name     string
+        input    string
+        expected bool
+        hasError bool
+    }{
+        {"Grouped with spaces", "2345 6789 0124", true, false},
+        {"Grouped with hyphens", "2345-6789-0124", true, false},
+        {"Unformatted", "234567890124", true, false},
+        {"Irregular separators", " 23-456 7890124 ", true, false},
+        {"Wrong checksum", "2345 6789 0125", false, false},
+        {"Too few digits", "2345 6789 012", false, true},
+        {"Too many digits", "2345 6789 01245", false, true},
+        {"Contains letters", "2345 6789 012a", false, true},
+        {"Other punctuation", "2345.6789.0124", false, true},
+        {"Separators only", " - ", false, true},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            got, err := ValidateAadhaarFormatted(tt.input)
+
+            if (err != nil) != tt.hasError {
+                t.Errorf("ValidateAadhaarFormatted() error = %v, wantErr %v",
+                    err, tt.hasError)
+                return
+            }
+
+            if got != tt.expected {
+                t.Errorf("ValidateAadhaarFormatted() = %v, want %v",
+                    got, tt.expected)
+            }
+        })
+    }
+    for input, offset := range map[string]int{"2345-6789-012x": 13, "2345 67.9 0124": 7} {
+        _, err := ValidateAadhaarFormatted(input)
+        var charErr *InvalidCharError
+        if !errors.As(err, &charErr) || charErr.Offset != offset {
+            t.Errorf("ValidateAadhaarFormatted(%q) error = %v, want offset %d", input, err, offset)
+        }
+    }
+}
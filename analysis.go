@@ -0,0 +1,126 @@
+// FilePath: analysis.go
+
+package verhoeff
+
+import (
+    "crypto/sha256"
+    "encoding/hex"
+)
+
+// Caps describes the error-detection guarantees of the lookup tables. The
+// values are derived from the tables each time rather than hard-coded.
+type Caps struct {
+    // SingleDigitErrors is true if every single-digit substitution is
+    // detected.
+    SingleDigitErrors bool
+    // AdjacentTranspositions is true if every swap of two different
+    // adjacent digits is detected.
+    AdjacentTranspositions bool
+    // UndetectedJumpTranspositions counts the jump transpositions (abc to
+    // cba with a != c) that go undetected, over all 8 position classes and
+    // all digit triples.
+    UndetectedJumpTranspositions int
+    // JumpTranspositions is the total number of jump transpositions
+    // considered, for turning UndetectedJumpTranspositions into a rate.
+    JumpTranspositions int
+}
+
+// segment runs the recurrence over digits starting at position pos (counted
+// from the right, as in validateChecksum) and returns the group product of
+// that stretch alone.
+func segment(pos int, digits ...int) int {
+    c := 0
+    for k, digit := range digits {
+        c = d[c][p[(pos+k)%8][digit]]
+    }
+    return c
+}
+
+// Capabilities analyses the lookup tables and reports which errors the
+// scheme is guaranteed to detect. A change is detected exactly when it alters
+// the group product of the affected stretch of digits, so only the 8 position
+// classes of the permutation table need to be examined.
+func Capabilities() Caps {
+    caps := Caps{SingleDigitErrors: true, AdjacentTranspositions: true}
+
+    for pos := 0; pos < 8; pos++ {
+        for a := 0; a < 10; a++ {
+            for b := 0; b < 10; b++ {
+                if a == b {
+                    continue
+                }
+                if segment(pos, a) == segment(pos, b) {
+                    caps.SingleDigitErrors = false
+                }
+                if segment(pos, a, b) == segment(pos, b, a) {
+                    caps.AdjacentTranspositions = false
+                }
+                for mid := 0; mid < 10; mid++ {
+                    caps.JumpTranspositions++
+                    if segment(pos, a, mid, b) == segment(pos, b, mid, a) {
+                        caps.UndetectedJumpTranspositions++
+                    }
+                }
+            }
+        }
+    }
+    return caps
+}
+
+// PositionContributions returns, for each digit of s, the permuted value
+// p[(i+1)%8][digit] that GenerateFromString feeds into the recurrence, where
+// i is the digit's distance from the right-hand end. The result is in input
+// order, so element j belongs to s[j]. Folding the values with d from the
+// last element to the first and applying inv gives the check digit, which
+// makes this useful for comparing another implementation's intermediate
+// values position by position.
+func PositionContributions(s string) ([]int, error) {
+    digits, err := stringToDigits(s)
+    if err != nil {
+        return nil, err
+    }
+
+    contributions := make([]int, len(digits))
+    for j, digit := range digits {
+        contributions[j] = p[(len(digits)-j)%8][digit]
+    }
+    return contributions, nil
+}
+
+// IsUniqueCheckDigit reports whether exactly one digit from 0 to 9 makes
+// base followed by that digit validate. With correct tables this always
+// holds, since the check digit is the group inverse of the payload's
+// product, so a false result means the tables are broken. It tries all ten
+// digits rather than relying on inv.
+func IsUniqueCheckDigit(base string) (bool, error) {
+    digits, err := stringToDigits(base)
+    if err != nil {
+        return false, err
+    }
+
+    digits = append(digits, 0)
+    matches := 0
+    for digit := 0; digit <= 9; digit++ {
+        digits[len(digits)-1] = digit
+        if validateChecksum(digits) {
+            matches++
+        }
+    }
+    return matches == 1, nil
+}
+
+// TablesFingerprint returns the hex-encoded SHA-256 of the lookup tables,
+// for detecting a change to them across releases. The hash covers every
+// entry of d, then p, then inv, row by row, each written as one byte. The
+// tables have a fixed shape, so no separators are needed.
+func TablesFingerprint() string {
+    h := sha256.New()
+    for _, table := range [][][]int{d, p, {inv}} {
+        for _, row := range table {
+            for _, v := range row {
+                h.Write([]byte{byte(v)})
+            }
+        }
+    }
+    return hex.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,171 @@
+// FilePath: analysis_test.go
+
+package verhoeff
+
+import (
+    "math/rand"
+    "testing"
+)
+
+func TestCapabilities(t *testing.T) {
+    caps := Capabilities()
+
+    expected := Caps{
+        SingleDigitErrors:            true,
+        AdjacentTranspositions:       true,
+        UndetectedJumpTranspositions: 416,
+        JumpTranspositions:           7200,
+    }
+    if caps != expected {
+        t.Errorf("Capabilities() = %+v, want %+v", caps, expected)
+    }
+}
+
+// fullProduct runs the validation recurrence over a whole number.
+func fullProduct(digits []int) int {
+    c := 0
+    for i := 0; i < len(digits); i++ {
+        c = d[c][p[i%8][digits[len(digits)-1-i]]]
+    }
+    return c
+}
+
+// TestCapabilitiesMatchBruteForce confirms the jump transposition analysis by
+// swapping digits inside whole numbers.
+func TestCapabilitiesMatchBruteForce(t *testing.T) {
+    undetected := 0
+    for pos := 0; pos < 8; pos++ {
+        for a := 0; a < 10; a++ {
+            for mid := 0; mid < 10; mid++ {
+                for b := 0; b < 10; b++ {
+                    if a == b {
+                        continue
+                    }
+                    // Surround the triple with filler so a, mid and b sit
+                    // at positions pos+2, pos+1 and pos from the right.
+                    digits := []int{4, 7, a, mid, b}
+                    for i := 0; i < pos; i++ {
+                        digits = append(digits, 7)
+                    }
+                    swapped := append([]int(nil), digits...)
+                    swapped[2], swapped[4] = b, a
+
+                    if fullProduct(digits) == fullProduct(swapped) {
+                        undetected++
+                    }
+                }
+            }
+        }
+    }
+
+    if caps := Capabilities(); caps.UndetectedJumpTranspositions != undetected {
+        t.Errorf("Capabilities() undetected = %d, brute force found %d",
+            caps.UndetectedJumpTranspositions, undetected)
+    }
+}
+
+func TestPositionContributions(t *testing.T) {
+    tests := []struct {
+        name     string
+        input    string
+        expected []int
+        hasError bool
+    }{
+        {"Five digits", "12345", []int{2, 5, 6, 7, 8}, false},
+        {"Wraps the permutation cycle", "123456789",
+            []int{5, 2, 6, 8, 7, 6, 5, 4, 4}, false},
+        {"Empty string", "", []int{}, false},
+        {"Non-digit", "12a45", nil, true},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            got, err := PositionContributions(tt.input)
+
+            if (err != nil) != tt.hasError {
+                t.Errorf("PositionContributions() error = %v, wantErr %v",
+                    err, tt.hasError)
+                return
+            }
+
+            if tt.hasError {
+                return
+            }
+
+            if len(got) != len(tt.expected) {
+                t.Errorf("PositionContributions() = %v, want %v",
+                    got, tt.expected)
+                return
+            }
+
+            for i := range got {
+                if got[i] != tt.expected[i] {
+                    t.Errorf("PositionContributions() = %v, want %v",
+                        got, tt.expected)
+                    return
+                }
+            }
+
+            // Folding the contributions must reproduce the check digit.
+            c := 0
+            for i := len(got) - 1; i >= 0; i-- {
+                c = d[c][got[i]]
+            }
+            want, _ := GenerateFromString(tt.input)
+            if inv[c] != want {
+                t.Errorf("folded contributions give %d, want %d", inv[c], want)
+            }
+        })
+    }
+}
+
+func TestIsUniqueCheckDigit(t *testing.T) {
+    tests := []struct {
+        name     string
+        input    string
+        expected bool
+        hasError bool
+    }{
+        {"Five digits", "12345", true, false},
+        {"Empty base", "", true, false},
+        {"Non-digit", "12a45", false, true},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            got, err := IsUniqueCheckDigit(tt.input)
+
+            if (err != nil) != tt.hasError {
+                t.Errorf("IsUniqueCheckDigit() error = %v, wantErr %v",
+                    err, tt.hasError)
+                return
+            }
+
+            if got != tt.expected {
+                t.Errorf("IsUniqueCheckDigit() = %v, want %v", got, tt.expected)
+            }
+        })
+    }
+
+    t.Run("Random bases", func(t *testing.T) {
+        rng := rand.New(rand.NewSource(3))
+        for i := 0; i < 500; i++ {
+            base := make([]byte, rng.Intn(25))
+            for j := range base {
+                base[j] = byte('0' + rng.Intn(10))
+            }
+            if unique, err := IsUniqueCheckDigit(string(base)); err != nil || !unique {
+                t.Fatalf("IsUniqueCheckDigit(%s) = %v, %v", base, unique, err)
+            }
+        }
+    })
+}
+
+// TestTablesFingerprint pins the fingerprint of the shipped tables. If it
+// fails, a table was edited and every stored check digit may be affected.
+func TestTablesFingerprint(t *testing.T) {
+    expected := "891552b38deffb245d547e7e57d7ea6a6ac308bbc50aa88f1be51e26ca603462"
+    if got := TablesFingerprint(); got != expected {
+        t.Errorf("TablesFingerprint() = %s, want %s", got, expected)
+    }
+}
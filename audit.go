@@ -0,0 +1,60 @@
+// FilePath: audit.go
+
+package verhoeff
+
+import (
+    "crypto/sha256"
+    "encoding/hex"
+    "time"
+)
+
+// AuditRecord is a structured, loggable record of a single validation.
+type AuditRecord struct {
+    // Input is the number that was validated. Use Redacted to drop it.
+    Input string
+    // Valid is true if the input passed validation.
+    Valid bool
+    // Expected is the checksum digit the payload calls for, or -1 if the
+    // input is malformed.
+    Expected int
+    // Error describes why the input could not be validated, if it could not.
+    Error string
+    // Timestamp is the time passed to CheckAudited.
+    Timestamp time.Time
+    // Hash is the hex-encoded SHA-256 of the input, which lets records for
+    // the same number be correlated. It is unkeyed and the space of valid
+    // numbers is small (about 10^11 for Aadhaar-like IDs), so the number can
+    // be recovered from it by brute force: it is not a privacy control, and
+    // a Redacted record that keeps Hash still identifies the number.
+    Hash string
+}
+
+// CheckAudited validates s and returns an audit record stamped with now.
+func CheckAudited(s string, now time.Time) AuditRecord {
+    sum := sha256.Sum256([]byte(s))
+    record := AuditRecord{
+        Input:     s,
+        Expected:  -1,
+        Timestamp: now,
+        Hash:      hex.EncodeToString(sum[:]),
+    }
+
+    valid, err := ValidateString(s)
+    if err != nil {
+        record.Error = err.Error()
+        return record
+    }
+    record.Valid = valid
+    // Slicing s would split a multi-byte final digit.
+    digits, _ := stringToDigits(s)
+    record.Expected = calculateChecksum(digits[:len(digits)-1])
+    return record
+}
+
+// Redacted returns a copy of the record with Input cleared, for logging
+// without retaining the number in plain text. Hash is kept; see its
+// documentation for why that does not hide the number.
+func (r AuditRecord) Redacted() AuditRecord {
+    r.Input = ""
+    return r
+}
@@ -0,0 +1,67 @@
+// FilePath: audit_test.go
+
+package verhoeff
+
+import (
+    "testing"
+    "time"
+)
+
+func TestCheckAudited(t *testing.T) {
+    now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+    tests := []struct {
+        name          string
+        input         string
+        expectedValid bool
+        expectedCheck int
+        hasError      bool
+    }{
+        {"Valid number", "2363", true, 3, false},
+        {"Invalid number", "2364", false, 3, false},
+        {"Non-ASCII check digit", "236३", false, 3, false},
+        {"Malformed number", "23a3", false, -1, true},
+        {"Empty input", "", false, -1, true},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            got := CheckAudited(tt.input, now)
+
+            if got.Valid != tt.expectedValid || got.Expected != tt.expectedCheck {
+                t.Errorf("CheckAudited() = %+v, want valid %v expected %d",
+                    got, tt.expectedValid, tt.expectedCheck)
+            }
+            if (got.Error != "") != tt.hasError {
+                t.Errorf("CheckAudited() Error = %q, wantErr %v",
+                    got.Error, tt.hasError)
+            }
+            if got.Input != tt.input || !got.Timestamp.Equal(now) {
+                t.Errorf("CheckAudited() = %+v, missing input or timestamp", got)
+            }
+            if len(got.Hash) != 64 {
+                t.Errorf("CheckAudited() Hash = %q, want 64 hex digits", got.Hash)
+            }
+        })
+    }
+
+    t.Run("Stable hash", func(t *testing.T) {
+        got := CheckAudited("2363", now)
+        want := "4da692391701da107842ef406baadd5915b3923ba2a06e156a337dd2d958e93e"
+        if got.Hash != want {
+            t.Errorf("CheckAudited() Hash = %s, want %s", got.Hash, want)
+        }
+    })
+
+    t.Run("Redacted", func(t *testing.T) {
+        record := CheckAudited("2363", now)
+        redacted := record.Redacted()
+        if redacted.Input != "" || redacted.Hash != record.Hash ||
+            !redacted.Valid {
+            t.Errorf("Redacted() = %+v", redacted)
+        }
+        if record.Input != "2363" {
+            t.Errorf("Redacted() modified the original record")
+        }
+    })
+}
@@ -0,0 +1,201 @@
+// FilePath: batch.go
+
+package verhoeff
+
+import (
+    "encoding/csv"
+    "errors"
+    "fmt"
+    "strconv"
+    "sync"
+)
+
+// ValidateCSVColumn validates column col of every record and returns the
+// 0-based indices of the records whose value fails validation. Values that
+// are empty or contain non-digit characters count as failures. Skipping a
+// header row is the caller's responsibility.
+func ValidateCSVColumn(records [][]string, col int) ([]int, error) {
+    if col < 0 {
+        return nil, fmt.Errorf("invalid column index: %d", col)
+    }
+
+    failed := []int{}
+    for i, record := range records {
+        if col >= len(record) {
+            return nil, fmt.Errorf("row %d has no column %d", i, col)
+        }
+        valid, err := ValidateString(record[col])
+        if err != nil || !valid {
+            failed = append(failed, i)
+        }
+    }
+    return failed, nil
+}
+
+// AppendChecksumCSV writes one row per base to w holding the base, the base
+// with its checksum digit appended and the checksum digit on its own. It
+// stops at the first base that fails, returning an error naming it, and
+// flushes w before returning in every case. As in AppendChecksumStringStrict,
+// an empty base fails with ErrEmptyInput rather than getting checksum 0.
+func AppendChecksumCSV(w *csv.Writer, bases []string) error {
+    for _, base := range bases {
+        if base == "" {
+            w.Flush()
+            return fmt.Errorf("base %q: %w", base, ErrEmptyInput)
+        }
+        checksum, err := GenerateFromString(base)
+        if err != nil {
+            w.Flush()
+            return fmt.Errorf("base %q: %w", base, err)
+        }
+        check := strconv.Itoa(checksum)
+        if err := w.Write([]string{base, base + check, check}); err != nil {
+            w.Flush()
+            return fmt.Errorf("base %q: %w", base, err)
+        }
+    }
+
+    w.Flush()
+    return w.Error()
+}
+
+// Summary aggregates the outcome of validating a collection of numbers.
+type Summary struct {
+    // ValidCount is the number of inputs with a correct checksum digit.
+    ValidCount int
+    // InvalidCount is the number of well-formed inputs with a wrong
+    // checksum digit.
+    InvalidCount int
+    // ErrorCount is the number of inputs that could not be validated.
+    ErrorCount int
+    // Errors counts the inputs that could not be validated by reason: the
+    // message of the sentinel error they match, such as ErrNonDigit, so
+    // inputs failing the same way share one key whatever the details.
+    Errors map[string]int
+}
+
+// errorReasons are the sentinel errors Summary groups failures by.
+var errorReasons = []error{ErrEmptyInput, ErrNonDigit, ErrInvalidDigit, ErrInvalidLength}
+
+// errorReason returns the message of the sentinel err matches, or the
+// message of err itself if it matches none.
+func errorReason(err error) string {
+    for _, reason := range errorReasons {
+        if errors.Is(err, reason) {
+            return reason.Error()
+        }
+    }
+    return err.Error()
+}
+
+// ValidateSummary validates every input and returns aggregate counts suitable
+// for a data-quality report. Malformed inputs are counted rather than
+// aborting the run.
+func ValidateSummary(inputs []string) Summary {
+    summary := Summary{Errors: map[string]int{}}
+    for _, input := range inputs {
+        valid, err := ValidateString(input)
+        switch {
+        case err != nil:
+            summary.ErrorCount++
+            summary.Errors[errorReason(err)]++
+        case valid:
+            summary.ValidCount++
+        default:
+            summary.InvalidCount++
+        }
+    }
+    return summary
+}
+
+// BatchResult is the outcome of validating one input in ValidateBatch.
+type BatchResult struct {
+    // Input is the string that was validated.
+    Input string
+    // Valid is true if Input has a correct checksum digit.
+    Valid bool
+    // Err is set if Input could not be validated.
+    Err error
+}
+
+// ValidateBatch validates every input independently and returns one result
+// per input, in input order. A malformed input only affects its own result.
+func ValidateBatch(inputs []string) []BatchResult {
+    results := make([]BatchResult, len(inputs))
+    for i, input := range inputs {
+        valid, err := ValidateString(input)
+        results[i] = BatchResult{Input: input, Valid: valid, Err: err}
+    }
+    return results
+}
+
+// ValidateBatchParallel works like ValidateBatch but spreads the inputs over
+// workers goroutines, which pays off for large batches. A workers value
+// below 1 is treated as 1. Results are in input order regardless of which
+// worker handled each input.
+func ValidateBatchParallel(inputs []string, workers int) []BatchResult {
+    if workers < 1 {
+        workers = 1
+    }
+
+    results := make([]BatchResult, len(inputs))
+    var wg sync.WaitGroup
+    for w := 0; w < workers; w++ {
+        wg.Add(1)
+        go func(start int) {
+            defer wg.Done()
+            // Each worker takes every workers-th input, so no two write the
+            // same element of results.
+            for i := start; i < len(inputs); i += workers {
+                valid, err := ValidateString(inputs[i])
+                results[i] = BatchResult{Input: inputs[i], Valid: valid, Err: err}
+            }
+        }(w)
+    }
+    wg.Wait()
+    return results
+}
+
+// Reconcile compares each base with the check digit stored for it and
+// returns the 0-based indices where the stored digit differs from the
+// computed one. A stored value outside 0-9 counts as a mismatch. It returns
+// an error if the slices differ in length or a base contains non-digit
+// characters.
+func Reconcile(bases []string, stored []int) ([]int, error) {
+    if len(bases) != len(stored) {
+        return nil, fmt.Errorf("got %d bases but %d stored check digits",
+            len(bases), len(stored))
+    }
+
+    mismatched := []int{}
+    for i, base := range bases {
+        checksum, err := GenerateFromString(base)
+        if err != nil {
+            return nil, fmt.Errorf("base %d %q: %w", i, base, err)
+        }
+        if checksum != stored[i] {
+            mismatched = append(mismatched, i)
+        }
+    }
+    return mismatched, nil
+}
+
+// ValidPredicate returns a function reporting whether a string is a valid
+// number, treating malformed input as not valid. It fits helpers such as
+// slices.IndexFunc and slices.ContainsFunc that take a func(string) bool.
+func ValidPredicate() func(string) bool {
+    return func(s string) bool {
+        valid, err := ValidateString(s)
+        return err == nil && valid
+    }
+}
+
+// InvalidPredicate returns the negation of ValidPredicate, reporting true
+// for numbers that are malformed or fail validation. Passing it to
+// slices.DeleteFunc keeps only the valid numbers.
+func InvalidPredicate() func(string) bool {
+    valid := ValidPredicate()
+    return func(s string) bool {
+        return !valid(s)
+    }
+}
@@ -0,0 +1,294 @@
+// FilePath: batch_test.go
+
+package verhoeff
+
+import (
+    "bytes"
+    "encoding/csv"
+    "errors"
+    "slices"
+    "strings"
+    "testing"
+)
+
+func TestValidateCSVColumn(t *testing.T) {
+    tests := []struct {
+        name     string
+        records  [][]string
+        col      int
+        expected []int
+        hasError bool
+    }{
+        {
+            "All valid",
+            [][]string{{"a", "2363"}, {"b", "123451"}},
+            1, []int{}, false,
+        },
+        {
+            "Mixed rows",
+            [][]string{{"2363"}, {"2364"}, {""}, {"12a4"}, {"1428570"}},
+            0, []int{1, 2, 3}, false,
+        },
+        {
+            "No records",
+            [][]string{},
+            0, []int{}, false,
+        },
+        {
+            "Short row",
+            [][]string{{"a", "2363"}, {"b"}},
+            1, nil, true,
+        },
+        {
+            "Negative column",
+            [][]string{{"2363"}},
+            -1, nil, true,
+        },
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            got, err := ValidateCSVColumn(tt.records, tt.col)
+
+            if (err != nil) != tt.hasError {
+                t.Errorf("ValidateCSVColumn() error = %v, wantErr %v",
+                    err, tt.hasError)
+                return
+            }
+
+            if tt.hasError {
+                return
+            }
+
+            if len(got) != len(tt.expected) {
+                t.Errorf("ValidateCSVColumn() = %v, want %v",
+                    got, tt.expected)
+                return
+            }
+
+            for i := range got {
+                if got[i] != tt.expected[i] {
+                    t.Errorf("ValidateCSVColumn() = %v, want %v",
+                        got, tt.expected)
+                    return
+                }
+            }
+        })
+    }
+}
+
+func TestAppendChecksumCSV(t *testing.T) {
+    t.Run("Writes rows", func(t *testing.T) {
+        var buf bytes.Buffer
+        w := csv.NewWriter(&buf)
+
+        err := AppendChecksumCSV(w, []string{"236", "12345"})
+        if err != nil {
+            t.Fatalf("AppendChecksumCSV() error = %v", err)
+        }
+
+        expected := "236,2363,3\n12345,123451,1\n"
+        if buf.String() != expected {
+            t.Errorf("AppendChecksumCSV() wrote %q, want %q",
+                buf.String(), expected)
+        }
+    })
+
+    t.Run("Stops at first bad base", func(t *testing.T) {
+        var buf bytes.Buffer
+        w := csv.NewWriter(&buf)
+
+        err := AppendChecksumCSV(w, []string{"236", "12a45", "12345"})
+        if err == nil {
+            t.Fatalf("AppendChecksumCSV() expected error")
+        }
+        if !strings.Contains(err.Error(), "12a45") {
+            t.Errorf("AppendChecksumCSV() error %q does not name the base", err)
+        }
+        if buf.String() != "236,2363,3\n" {
+            t.Errorf("AppendChecksumCSV() wrote %q before failing", buf.String())
+        }
+    })
+
+    t.Run("Rejects empty base", func(t *testing.T) {
+        var buf bytes.Buffer
+        w := csv.NewWriter(&buf)
+
+        err := AppendChecksumCSV(w, []string{"236", "", "12345"})
+        if !errors.Is(err, ErrEmptyInput) {
+            t.Fatalf("AppendChecksumCSV() error = %v, want ErrEmptyInput", err)
+        }
+        if buf.String() != "236,2363,3\n" {
+            t.Errorf("AppendChecksumCSV() wrote %q before failing", buf.String())
+        }
+    })
+}
+
+func TestValidateSummary(t *testing.T) {
+    inputs := []string{"2363", "123451", "2364", "", "12a4", "", "1428570", "b363"}
+    got := ValidateSummary(inputs)
+
+    if got.ValidCount != 3 || got.InvalidCount != 1 || got.ErrorCount != 4 {
+        t.Errorf("ValidateSummary() counts = %d/%d/%d, want 3/1/4",
+            got.ValidCount, got.InvalidCount, got.ErrorCount)
+    }
+
+    if got.Errors[ErrEmptyInput.Error()] != 2 {
+        t.Errorf("ValidateSummary() empty input count = %d, want 2",
+            got.Errors[ErrEmptyInput.Error()])
+    }
+
+    if got.Errors[ErrNonDigit.Error()] != 2 {
+        t.Errorf("ValidateSummary() non-digit count = %d, want 2",
+            got.Errors[ErrNonDigit.Error()])
+    }
+
+    if len(got.Errors) != 2 {
+        t.Errorf("ValidateSummary() error reasons = %v, want 2 entries",
+            got.Errors)
+    }
+
+    t.Run("No inputs", func(t *testing.T) {
+        empty := ValidateSummary(nil)
+        if empty.ValidCount+empty.InvalidCount+empty.ErrorCount != 0 {
+            t.Errorf("ValidateSummary(nil) = %+v, want zero counts", empty)
+        }
+        if empty.Errors == nil {
+            t.Errorf("ValidateSummary(nil) Errors map is nil")
+        }
+    })
+}
+
+func TestValidateBatch(t *testing.T) {
+    inputs := []string{"2363", "2364", "", "12a4", "123451"}
+    want := []BatchResult{
+        {Input: "2363", Valid: true},
+        {Input: "2364", Valid: false},
+        {Input: "", Err: ErrEmptyInput},
+        {Input: "12a4", Err: errors.New("input contains non-digit characters")},
+        {Input: "123451", Valid: true},
+    }
+
+    check := func(t *testing.T, name string, got []BatchResult) {
+        if len(got) != len(want) {
+            t.Fatalf("%s() returned %d results, want %d", name, len(got), len(want))
+        }
+        for i := range want {
+            if got[i].Input != want[i].Input || got[i].Valid != want[i].Valid ||
+                (got[i].Err != nil) != (want[i].Err != nil) {
+                t.Errorf("%s()[%d] = %+v, want %+v", name, i, got[i], want[i])
+            }
+        }
+    }
+
+    check(t, "ValidateBatch", ValidateBatch(inputs))
+    for _, workers := range []int{0, 1, 3, 16} {
+        check(t, "ValidateBatchParallel", ValidateBatchParallel(inputs, workers))
+    }
+
+    t.Run("Large batch keeps order", func(t *testing.T) {
+        large := make([]string, 1000)
+        for i := range large {
+            large[i] = AppendChecksumInt(i)
+            if i%7 == 0 {
+                large[i] += "1"
+            }
+        }
+        sequential := ValidateBatch(large)
+        parallel := ValidateBatchParallel(large, 8)
+        for i := range large {
+            if parallel[i] != sequential[i] {
+                t.Fatalf("ValidateBatchParallel()[%d] = %+v, want %+v", i, parallel[i], sequential[i])
+            }
+        }
+    })
+
+    if got := ValidateBatchParallel(nil, 4); len(got) != 0 {
+        t.Errorf("ValidateBatchParallel(nil) = %v, want empty", got)
+    }
+}
+
+func TestReconcile(t *testing.T) {
+    tests := []struct {
+        name     string
+        bases    []string
+        stored   []int
+        expected []int
+        hasError bool
+    }{
+        {
+            "All match",
+            []string{"236", "12345", ""},
+            []int{3, 1, 0},
+            []int{}, false,
+        },
+        {
+            "Mismatches",
+            []string{"236", "12345", "142857"},
+            []int{4, 1, 10},
+            []int{0, 2}, false,
+        },
+        {
+            "No rows",
+            nil, nil,
+            []int{}, false,
+        },
+        {
+            "Length mismatch",
+            []string{"236", "12345"},
+            []int{3},
+            nil, true,
+        },
+        {
+            "Non-digit base",
+            []string{"236", "12a45"},
+            []int{3, 1},
+            nil, true,
+        },
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            got, err := Reconcile(tt.bases, tt.stored)
+
+            if (err != nil) != tt.hasError {
+                t.Errorf("Reconcile() error = %v, wantErr %v", err, tt.hasError)
+                return
+            }
+
+            if tt.hasError {
+                return
+            }
+
+            if len(got) != len(tt.expected) {
+                t.Errorf("Reconcile() = %v, want %v", got, tt.expected)
+                return
+            }
+
+            for i := range got {
+                if got[i] != tt.expected[i] {
+                    t.Errorf("Reconcile() = %v, want %v", got, tt.expected)
+                    return
+                }
+            }
+        })
+    }
+}
+
+func TestPredicates(t *testing.T) {
+    ids := []string{"2363", "2364", "", "12a4", "123451"}
+
+    kept := slices.DeleteFunc(slices.Clone(ids), InvalidPredicate())
+    if !slices.Equal(kept, []string{"2363", "123451"}) {
+        t.Errorf("slices.DeleteFunc(InvalidPredicate()) = %v", kept)
+    }
+
+    dropped := slices.DeleteFunc(slices.Clone(ids), ValidPredicate())
+    if !slices.Equal(dropped, []string{"2364", "", "12a4"}) {
+        t.Errorf("slices.DeleteFunc(ValidPredicate()) = %v", dropped)
+    }
+
+    if i := slices.IndexFunc(ids, ValidPredicate()); i != 0 {
+        t.Errorf("slices.IndexFunc(ValidPredicate()) = %d, want 0", i)
+    }
+}
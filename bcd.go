@@ -0,0 +1,112 @@
+// FilePath: bcd.go
+
+package verhoeff
+
+import (
+    "encoding/binary"
+    "errors"
+    "fmt"
+)
+
+// PackBinary validates s and packs it as binary-coded decimal for compact
+// storage. The output is the digit count as an unsigned varint, followed by
+// the digits two per byte, most significant first, each byte holding the
+// earlier digit in its high nibble. For an odd count the high nibble of the
+// first digit byte is a zero pad: "2363" packs to 04 23 63 and "121" to
+// 03 01 21. It returns ErrChecksumMismatch if s fails validation.
+func PackBinary(s string) ([]byte, error) {
+    digits, err := stringToDigits(s)
+    if err != nil {
+        return nil, err
+    }
+    if len(digits) == 0 {
+        return nil, ErrEmptyInput
+    }
+    if !validateChecksum(digits) {
+        return nil, ErrChecksumMismatch
+    }
+
+    packed := binary.AppendUvarint(nil, uint64(len(digits)))
+    if len(digits)%2 == 1 {
+        packed = append(packed, byte(digits[0]))
+        digits = digits[1:]
+    }
+    for i := 0; i < len(digits); i += 2 {
+        packed = append(packed, byte(digits[i]<<4|digits[i+1]))
+    }
+    return packed, nil
+}
+
+// UnpackBinary reverses PackBinary and validates the result, returning
+// ErrChecksumMismatch if the number no longer passes. It returns an error if
+// the header is malformed, the data is truncated or has trailing bytes, a
+// nibble is not a decimal digit, or the pad nibble is not zero.
+func UnpackBinary(data []byte) (string, error) {
+    count, n := binary.Uvarint(data)
+    if n <= 0 {
+        return "", errors.New("invalid length header")
+    }
+    if count == 0 {
+        return "", ErrEmptyInput
+    }
+    body := data[n:]
+    // count/2 + count%2 rounds up without overflowing on huge headers.
+    if want := count/2 + count%2; uint64(len(body)) != want {
+        return "", fmt.Errorf("expected %d bytes of digits for %d digits, got %d",
+            want, count, len(body))
+    }
+
+    digits := make([]byte, 0, count)
+    for i, b := range body {
+        high, low := b>>4, b&0x0f
+        if high > 9 || low > 9 {
+            return "", fmt.Errorf("invalid BCD byte 0x%02x at offset %d", b, n+i)
+        }
+        if i == 0 && count%2 == 1 {
+            if high != 0 {
+                return "", fmt.Errorf("non-zero pad nibble in byte 0x%02x", b)
+            }
+            digits = append(digits, '0'+low)
+            continue
+        }
+        digits = append(digits, '0'+high, '0'+low)
+    }
+
+    valid, err := ValidateBytes(digits)
+    if err != nil {
+        return "", err
+    }
+    if !valid {
+        return "", ErrChecksumMismatch
+    }
+    return string(digits), nil
+}
+
+// GenerateBCD calculates the check digit for digitCount digits stored as
+// packed BCD in b, two per byte with the earlier digit in the high nibble,
+// without converting them to a string. b must hold exactly
+// (digitCount+1)/2 bytes. For an odd digitCount the digits are left-aligned
+// and the low nibble of the last byte is ignored, which differs from the
+// leading pad PackBinary uses. It returns an error for a nibble above 9.
+func GenerateBCD(b []byte, digitCount int) (int, error) {
+    if digitCount < 0 {
+        return -1, errors.New("digit count must not be negative")
+    }
+    if len(b) != (digitCount+1)/2 {
+        return -1, fmt.Errorf("expected %d bytes for %d digits, got %d",
+            (digitCount+1)/2, digitCount, len(b))
+    }
+
+    c := 0
+    for k := digitCount - 1; k >= 0; k-- {
+        digit := b[k/2] & 0x0f
+        if k%2 == 0 {
+            digit = b[k/2] >> 4
+        }
+        if digit > 9 {
+            return -1, fmt.Errorf("invalid BCD nibble 0x%x at digit %d", digit, k)
+        }
+        c = d[c][p[(digitCount-k)%8][digit]]
+    }
+    return inv[c], nil
+}
@@ -0,0 +1,143 @@
+// FilePath: bcd_test.go
+
+package verhoeff
+
+import (
+    "bytes"
+    "errors"
+    "strings"
+    "testing"
+)
+
+func TestPackBinary(t *testing.T) {
+    tests := []struct {
+        name     string
+        input    string
+        expected []byte
+        hasError bool
+    }{
+        {"Even digit count", "2363", []byte{0x04, 0x23, 0x63}, false},
+        {"Odd digit count", "121", []byte{0x03, 0x01, 0x21}, false},
+        {"Single digit", "0", []byte{0x01, 0x00}, false},
+        {"Six digits", "123451", []byte{0x06, 0x12, 0x34, 0x51}, false},
+        {"Invalid checksum", "2364", nil, true},
+        {"Empty string", "", nil, true},
+        {"Non-digit", "23a3", nil, true},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            got, err := PackBinary(tt.input)
+
+            if (err != nil) != tt.hasError {
+                t.Errorf("PackBinary() error = %v, wantErr %v", err, tt.hasError)
+                return
+            }
+
+            if !bytes.Equal(got, tt.expected) {
+                t.Errorf("PackBinary() = % x, want % x", got, tt.expected)
+            }
+
+            if tt.hasError {
+                return
+            }
+
+            back, err := UnpackBinary(got)
+            if err != nil || back != tt.input {
+                t.Errorf("UnpackBinary() = %q, %v, want %q", back, err, tt.input)
+            }
+        })
+    }
+
+    t.Run("Long number", func(t *testing.T) {
+        long, _ := AppendChecksumString(strings.Repeat("1234567890", 30))
+        packed, err := PackBinary(long)
+        if err != nil {
+            t.Fatalf("PackBinary() error = %v", err)
+        }
+        // 301 digits need a two-byte varint header and 151 digit bytes.
+        if len(packed) != 2+151 {
+            t.Errorf("PackBinary() produced %d bytes, want 153", len(packed))
+        }
+        if back, err := UnpackBinary(packed); err != nil || back != long {
+            t.Errorf("UnpackBinary() round trip failed: %v", err)
+        }
+    })
+}
+
+func TestUnpackBinary(t *testing.T) {
+    tests := []struct {
+        name     string
+        input    []byte
+        expected string
+        hasError bool
+    }{
+        {"Valid", []byte{0x04, 0x23, 0x63}, "2363", false},
+        {"Corrupted digit", []byte{0x04, 0x23, 0x64}, "", true},
+        {"Non-decimal nibble", []byte{0x04, 0x2a, 0x63}, "", true},
+        {"Non-zero pad nibble", []byte{0x03, 0x11, 0x21}, "", true},
+        {"Truncated", []byte{0x04, 0x23}, "", true},
+        {"Trailing bytes", []byte{0x04, 0x23, 0x63, 0x00}, "", true},
+        {"Zero length", []byte{0x00}, "", true},
+        {"Missing header", []byte{}, "", true},
+        {"Maximum count header", []byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x01}, "", true},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            got, err := UnpackBinary(tt.input)
+
+            if (err != nil) != tt.hasError {
+                t.Errorf("UnpackBinary() error = %v, wantErr %v",
+                    err, tt.hasError)
+                return
+            }
+
+            if got != tt.expected {
+                t.Errorf("UnpackBinary() = %v, want %v", got, tt.expected)
+            }
+        })
+    }
+
+    t.Run("Corruption reports ErrChecksumMismatch", func(t *testing.T) {
+        _, err := UnpackBinary([]byte{0x04, 0x23, 0x64})
+        if !errors.Is(err, ErrChecksumMismatch) {
+            t.Errorf("UnpackBinary() error = %v, want ErrChecksumMismatch", err)
+        }
+    })
+}
+
+func TestGenerateBCD(t *testing.T) {
+    tests := []struct {
+        name          string
+        input         []byte
+        digitCount    int
+        expectedDigit int
+        hasError      bool
+    }{
+        {"Even count", []byte{0x12, 0x34}, 4, 0, false},
+        {"Odd count ignores last low nibble", []byte{0x12, 0x34, 0x5f}, 5, 1, false},
+        {"Three digits", []byte{0x23, 0x60}, 3, 3, false},
+        {"No digits", []byte{}, 0, 0, false},
+        {"Nibble above 9", []byte{0x1a, 0x34}, 4, -1, true},
+        {"Too few bytes", []byte{0x12}, 4, -1, true},
+        {"Too many bytes", []byte{0x12, 0x34, 0x50}, 4, -1, true},
+        {"Negative count", []byte{}, -1, -1, true},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            got, err := GenerateBCD(tt.input, tt.digitCount)
+
+            if (err != nil) != tt.hasError {
+                t.Errorf("GenerateBCD() error = %v, wantErr %v",
+                    err, tt.hasError)
+                return
+            }
+
+            if got != tt.expectedDigit {
+                t.Errorf("GenerateBCD() = %v, want %v", got, tt.expectedDigit)
+            }
+        })
+    }
+}
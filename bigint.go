@@ -0,0 +1,73 @@
+// FilePath: bigint.go
+
+package verhoeff
+
+import (
+    "errors"
+    "math/big"
+)
+
+// errNilBigInt is returned for a nil *big.Int.
+var errNilBigInt = errors.New("nil *big.Int")
+
+// bigIntToDigits converts the magnitude of n to a slice of digits. It goes
+// through the decimal string, which big.Int builds in one pass, rather than
+// dividing by ten once per digit.
+func bigIntToDigits(n *big.Int) ([]int, error) {
+    if n == nil {
+        return nil, errNilBigInt
+    }
+
+    text := n.Text(10)
+    if n.Sign() < 0 {
+        text = text[1:]
+    }
+    digits := make([]int, len(text))
+    for i := 0; i < len(text); i++ {
+        digits[i] = int(text[i] - '0')
+    }
+    return digits, nil
+}
+
+// GenerateBigInt calculates the Verhoeff checksum digit for a big.Int, for
+// identifiers too large for int64. Negative numbers are treated by absolute
+// value, as in GenerateInt64.
+func GenerateBigInt(n *big.Int) (int, error) {
+    digits, err := bigIntToDigits(n)
+    if err != nil {
+        return -1, err
+    }
+    return calculateChecksum(digits), nil
+}
+
+// ValidateBigInt checks if a big.Int with its checksum digit is valid.
+// Negative numbers are treated by absolute value, and nil is not valid.
+func ValidateBigInt(n *big.Int) bool {
+    digits, err := bigIntToDigits(n)
+    if err != nil {
+        return false
+    }
+    return validateChecksum(digits)
+}
+
+// AppendChecksumBigInt returns a new big.Int with the checksum digit of n
+// appended, so 236 gives 2363. The sign of n is kept, matching the string
+// AppendChecksumInt64 builds: -236 gives -2363. n is not modified.
+//
+// As an integer the result cannot keep a leading zero, so for n = 0 it is 4
+// rather than 04, and does not validate; use AppendChecksum for the string
+// form.
+func AppendChecksumBigInt(n *big.Int) (*big.Int, error) {
+    checksum, err := GenerateBigInt(n)
+    if err != nil {
+        return nil, err
+    }
+
+    result := new(big.Int).Abs(n)
+    result.Mul(result, big.NewInt(10))
+    result.Add(result, big.NewInt(int64(checksum)))
+    if n.Sign() < 0 {
+        result.Neg(result)
+    }
+    return result, nil
+}
@@ -0,0 +1,108 @@
+// FilePath: bigint_test.go
+
+package verhoeff
+
+import (
+    "math/big"
+    "testing"
+)
+
+// mustBigInt parses a decimal string into a big.Int for test tables.
+func mustBigInt(s string) *big.Int {
+    n, ok := new(big.Int).SetString(s, 10)
+    if !ok {
+        panic("invalid big.Int literal: " + s)
+    }
+    return n
+}
+
+func TestGenerateBigInt(t *testing.T) {
+    tests := []struct {
+        name             string
+        input            *big.Int
+        expectedChecksum int
+        expectedAppended string
+        hasError         bool
+    }{
+        {"Small number", big.NewInt(236), 3, "2363", false},
+        {"Zero", big.NewInt(0), 4, "4", false},
+        {"Beyond uint64", mustBigInt("18446744073709551616"), 9, "184467440737095516169", false},
+        {"Thirty digits", mustBigInt("123456789012345678901234567890"), 3,
+            "1234567890123456789012345678903", false},
+        {"Negative number", big.NewInt(-236), 3, "-2363", false},
+        {"Nil pointer", nil, -1, "", true},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            checksum, err := GenerateBigInt(tt.input)
+            if (err != nil) != tt.hasError {
+                t.Errorf("GenerateBigInt() error = %v, wantErr %v", err, tt.hasError)
+                return
+            }
+            if checksum != tt.expectedChecksum {
+                t.Errorf("GenerateBigInt() = %v, want %v", checksum, tt.expectedChecksum)
+            }
+
+            appended, err := AppendChecksumBigInt(tt.input)
+            if (err != nil) != tt.hasError {
+                t.Errorf("AppendChecksumBigInt() error = %v, wantErr %v", err, tt.hasError)
+                return
+            }
+            if !tt.hasError && appended.String() != tt.expectedAppended {
+                t.Errorf("AppendChecksumBigInt() = %v, want %v", appended, tt.expectedAppended)
+            }
+        })
+    }
+}
+
+func TestValidateBigInt(t *testing.T) {
+    tests := []struct {
+        name     string
+        input    *big.Int
+        expected bool
+    }{
+        {"Valid", big.NewInt(2363), true},
+        {"Invalid", big.NewInt(2364), false},
+        {"Beyond uint64 valid", mustBigInt("184467440737095516169"), true},
+        {"Beyond uint64 invalid", mustBigInt("184467440737095516168"), false},
+        {"Negative valid", big.NewInt(-2363), true},
+        {"Nil pointer", nil, false},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            if got := ValidateBigInt(tt.input); got != tt.expected {
+                t.Errorf("ValidateBigInt() = %v, want %v", got, tt.expected)
+            }
+        })
+    }
+}
+
+func TestBigIntDispatch(t *testing.T) {
+    n := mustBigInt("123456789012345678901234567890")
+
+    if got, err := Generate(n); err != nil || got != 3 {
+        t.Errorf("Generate(*big.Int) = (%v, %v), want (3, nil)", got, err)
+    }
+    if got, err := Validate(mustBigInt("1234567890123456789012345678903")); err != nil || !got {
+        t.Errorf("Validate(*big.Int) = (%v, %v), want (true, nil)", got, err)
+    }
+    if got, err := AppendChecksum(n); err != nil || got != "1234567890123456789012345678903" {
+        t.Errorf("AppendChecksum(*big.Int) = (%v, %v), want (1234567890123456789012345678903, nil)", got, err)
+    }
+    if got, err := AppendChecksum(big.NewInt(0)); err != nil || got != "04" {
+        t.Errorf("AppendChecksum(*big.Int 0) = (%v, %v), want (04, nil)", got, err)
+    }
+
+    var nilInt *big.Int
+    if _, err := Generate(nilInt); err == nil {
+        t.Errorf("Generate(nil *big.Int) expected error")
+    }
+    if _, err := Validate(nilInt); err == nil {
+        t.Errorf("Validate(nil *big.Int) expected error")
+    }
+    if _, err := AppendChecksum(nilInt); err == nil {
+        t.Errorf("AppendChecksum(nil *big.Int) expected error")
+    }
+}
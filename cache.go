@@ -0,0 +1,93 @@
+// FilePath: cache.go
+
+package verhoeff
+
+import (
+    "errors"
+    "hash/fnv"
+    "sync"
+)
+
+// HashedCache memoizes validation results keyed by a hash of the input
+// instead of the input itself, so sensitive numbers are never retained in
+// plaintext. It holds at most size results and evicts the oldest first. It
+// is safe for concurrent use.
+//
+// Because only the hash is kept, a cached result cannot be checked against
+// the input that produced it: two inputs whose hashes collide share one
+// entry, and the second gets the first's result. HashedCache accepts that
+// risk rather than storing the input. With a well-mixed 64-bit hash the
+// chance of any collision among n distinct inputs is about n*n/2^65, which
+// is negligible for realistic n; a weak h raises it accordingly.
+type HashedCache struct {
+    mu      sync.Mutex
+    hash    func([]byte) uint64
+    results map[uint64]bool
+    order   []uint64 // ring buffer of keys in insertion order
+    next    int
+}
+
+// NewHashedCache returns a cache holding up to size results, keyed by h
+// applied to the input bytes. A nil h selects 64-bit FNV-1a. size must be
+// positive.
+func NewHashedCache(size int, h func([]byte) uint64) (*HashedCache, error) {
+    if size <= 0 {
+        return nil, errors.New("cache size must be positive")
+    }
+    if h == nil {
+        h = fnv64a
+    }
+    return &HashedCache{
+        hash:    h,
+        results: make(map[uint64]bool, size),
+        order:   make([]uint64, 0, size),
+    }, nil
+}
+
+// fnv64a hashes b with 64-bit FNV-1a.
+func fnv64a(b []byte) uint64 {
+    h := fnv.New64a()
+    h.Write(b)
+    return h.Sum64()
+}
+
+// Validate returns the cached result for s if there is one and otherwise
+// validates s with ValidateString and caches the result. Inputs that
+// produce an error are not cached.
+func (c *HashedCache) Validate(s string) (bool, error) {
+    key := c.hash([]byte(s))
+
+    c.mu.Lock()
+    valid, ok := c.results[key]
+    c.mu.Unlock()
+    if ok {
+        return valid, nil
+    }
+
+    valid, err := ValidateString(s)
+    if err != nil {
+        return false, err
+    }
+
+    c.mu.Lock()
+    defer c.mu.Unlock()
+    if _, ok := c.results[key]; ok {
+        return valid, nil
+    }
+    if len(c.order) < cap(c.order) {
+        c.order = append(c.order, key)
+    } else {
+        delete(c.results, c.order[c.next])
+        c.order[c.next] = key
+        c.next = (c.next + 1) % len(c.order)
+    }
+    c.results[key] = valid
+    return valid, nil
+}
+
+// Len returns the number of cached results.
+func (c *HashedCache) Len() int {
+    c.mu.Lock()
+    defer c.mu.Unlock()
+    return len(c.results)
+}
@@ -0,0 +1,91 @@
+// FilePath: cache_test.go
+
+package verhoeff
+
+import (
+    "sync"
+    "testing"
+)
+
+func TestHashedCache(t *testing.T) {
+    cache, err := NewHashedCache(2, nil)
+    if err != nil {
+        t.Fatalf("NewHashedCache() error = %v", err)
+    }
+
+    tests := []struct {
+        name     string
+        input    string
+        expected bool
+        hasError bool
+        length   int
+    }{
+        {"First valid", "2363", true, false, 1},
+        {"Repeat is a hit", "2363", true, false, 1},
+        {"Invalid", "2364", false, false, 2},
+        {"Errors are not cached", "12a4", false, true, 2},
+        {"Evicts the oldest", "123451", true, false, 2},
+        {"Evicted entry recomputed", "2363", true, false, 2},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            got, err := cache.Validate(tt.input)
+
+            if (err != nil) != tt.hasError {
+                t.Errorf("Validate() error = %v, wantErr %v", err, tt.hasError)
+                return
+            }
+
+            if got != tt.expected {
+                t.Errorf("Validate() = %v, want %v", got, tt.expected)
+            }
+
+            if cache.Len() != tt.length {
+                t.Errorf("Len() = %d, want %d", cache.Len(), tt.length)
+            }
+        })
+    }
+
+    t.Run("Invalid size", func(t *testing.T) {
+        if _, err := NewHashedCache(0, nil); err == nil {
+            t.Errorf("NewHashedCache(0) expected error")
+        }
+    })
+}
+
+// TestHashedCacheCollision documents the accepted risk: inputs whose hashes
+// collide share a result.
+func TestHashedCacheCollision(t *testing.T) {
+    cache, _ := NewHashedCache(4, func([]byte) uint64 { return 42 })
+
+    if got, _ := cache.Validate("2363"); !got {
+        t.Fatalf("Validate(2363) = false, want true")
+    }
+    if got, _ := cache.Validate("2364"); !got {
+        t.Errorf("Validate(2364) = false, want the colliding entry's true")
+    }
+}
+
+func TestHashedCacheConcurrent(t *testing.T) {
+    cache, _ := NewHashedCache(16, nil)
+
+    var wg sync.WaitGroup
+    for g := 0; g < 8; g++ {
+        wg.Add(1)
+        go func(g int) {
+            defer wg.Done()
+            for i := 0; i < 200; i++ {
+                number := AppendChecksumInt(g*1000 + i%40)
+                if valid, err := cache.Validate(number); err != nil || !valid {
+                    t.Errorf("Validate(%s) = %v, %v", number, valid, err)
+                }
+            }
+        }(g)
+    }
+    wg.Wait()
+
+    if cache.Len() > 16 {
+        t.Errorf("Len() = %d, want at most 16", cache.Len())
+    }
+}
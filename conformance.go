@@ -0,0 +1,41 @@
+// FilePath: conformance.go
+
+package verhoeff
+
+import (
+    "math/rand"
+)
+
+// maxVectorLength is the longest input ConformanceVectors generates. It
+// spans several cycles of the 8-row permutation table.
+const maxVectorLength = 32
+
+// Vector is a single conformance test case: an input of ASCII digits and the
+// check digit this implementation computes for it. The JSON tags give a
+// language-neutral serialized form for other implementations to consume.
+type Vector struct {
+    Input            string `json:"input"`
+    ExpectedChecksum int    `json:"expected_checksum"`
+}
+
+// ConformanceVectors returns count test vectors drawn deterministically from
+// seed: the same seed always yields the same vectors. Inputs are 1 to 32
+// digits long and may have leading zeros. It returns an empty slice if count
+// is not positive.
+func ConformanceVectors(count int, seed int64) []Vector {
+    if count <= 0 {
+        return []Vector{}
+    }
+
+    rng := rand.New(rand.NewSource(seed))
+    vectors := make([]Vector, count)
+    for i := range vectors {
+        input := make([]byte, 1+rng.Intn(maxVectorLength))
+        for j := range input {
+            input[j] = byte('0' + rng.Intn(10))
+        }
+        checksum, _ := GenerateBytes(input)
+        vectors[i] = Vector{Input: string(input), ExpectedChecksum: checksum}
+    }
+    return vectors
+}
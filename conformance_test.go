@@ -0,0 +1,68 @@
+// FilePath: conformance_test.go
+
+package verhoeff
+
+import (
+    "encoding/json"
+    "strings"
+    "testing"
+)
+
+func TestConformanceVectors(t *testing.T) {
+    vectors := ConformanceVectors(200, 1)
+    if len(vectors) != 200 {
+        t.Fatalf("ConformanceVectors() returned %d vectors, want 200",
+            len(vectors))
+    }
+
+    // Pinned so that a change to the generator, which would silently
+    // invalidate exported suites, fails here.
+    first := Vector{Input: "77", ExpectedChecksum: 4}
+    if vectors[0] != first {
+        t.Errorf("ConformanceVectors()[0] = %+v, want %+v", vectors[0], first)
+    }
+
+    again := ConformanceVectors(200, 1)
+    for i := range vectors {
+        if vectors[i] != again[i] {
+            t.Fatalf("ConformanceVectors() is not deterministic at %d", i)
+        }
+    }
+
+    for _, v := range vectors {
+        if len(v.Input) < 1 || len(v.Input) > maxVectorLength {
+            t.Errorf("vector input %q has length %d", v.Input, len(v.Input))
+        }
+        want, err := GenerateFromString(v.Input)
+        if err != nil || want != v.ExpectedChecksum {
+            t.Errorf("vector %+v, GenerateFromString() = %d, %v",
+                v, want, err)
+        }
+    }
+
+    if other := ConformanceVectors(1, 2); other[0] == first {
+        t.Errorf("ConformanceVectors() ignores the seed")
+    }
+
+    if empty := ConformanceVectors(0, 1); len(empty) != 0 {
+        t.Errorf("ConformanceVectors(0) = %v, want empty", empty)
+    }
+}
+
+func TestVectorJSON(t *testing.T) {
+    data, err := json.Marshal(Vector{Input: "12345", ExpectedChecksum: 1})
+    if err != nil {
+        t.Fatalf("json.Marshal() error = %v", err)
+    }
+
+    expected := `{"input":"12345","expected_checksum":1}`
+    if string(data) != expected {
+        t.Errorf("json.Marshal() = %s, want %s", data, expected)
+    }
+
+    var decoded []Vector
+    err = json.NewDecoder(strings.NewReader("[" + expected + "]")).Decode(&decoded)
+    if err != nil || len(decoded) != 1 || decoded[0].ExpectedChecksum != 1 {
+        t.Errorf("decoding %s = %+v, %v", expected, decoded, err)
+    }
+}
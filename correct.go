@@ -0,0 +1,539 @@
+// FilePath: correct.go
+
+package verhoeff
+
+import (
+    "fmt"
+    "math"
+)
+
+// MinimalFix finds a single digit substitution that turns an invalid number
+// into a valid one. It returns the position and the replacement digit, or
+// ok=false if s is already valid. Candidates are tried from the lowest
+// position upwards and, within a position, from the lowest digit upwards,
+// so the result is deterministic.
+//
+// Because every position contributes through a permutation, any invalid
+// number can be repaired at any single position; the fix returned is simply
+// the first one in that order, not necessarily the digit the user mistyped.
+func MinimalFix(s string) (pos int, newDigit int, ok bool, err error) {
+    digits, err := stringToDigits(s)
+    if err != nil {
+        return -1, -1, false, err
+    }
+    if len(digits) == 0 {
+        return -1, -1, false, ErrEmptyInput
+    }
+    if validateChecksum(digits) {
+        return -1, -1, false, nil
+    }
+
+    fixes := singleDigitFixes(digits, 1)
+    if len(fixes) == 0 {
+        return -1, -1, false, nil
+    }
+    return fixes[0].pos, fixes[0].digit, true, nil
+}
+
+// fix is a single-digit substitution: the digit at pos replaced by digit.
+type fix struct {
+    pos   int
+    digit int
+}
+
+// apply returns digits with f applied, formatted as ASCII digits. digits
+// itself is left unchanged.
+func (f fix) apply(digits []int) string {
+    original := digits[f.pos]
+    digits[f.pos] = f.digit
+    result := digitsToString(digits)
+    digits[f.pos] = original
+    return result
+}
+
+// singleDigitFixes returns the single-digit substitutions that make digits
+// valid, ordered by position and then by digit, stopping after limit of
+// them if limit is positive. digits is modified while searching but
+// restored before returning.
+func singleDigitFixes(digits []int, limit int) []fix {
+    fixes := []fix{}
+    for i, original := range digits {
+        for digit := 0; digit <= 9; digit++ {
+            if digit == original {
+                continue
+            }
+            digits[i] = digit
+            if validateChecksum(digits) {
+                fixes = append(fixes, fix{pos: i, digit: digit})
+                if len(fixes) == limit {
+                    digits[i] = original
+                    return fixes
+                }
+            }
+        }
+        digits[i] = original
+    }
+    return fixes
+}
+
+// Fix returns the valid number closest to s, in the fewest single-digit
+// substitutions, using at most maxEdits of them. A valid s is returned
+// unchanged with edits 0; otherwise the corrected number is returned in
+// ASCII digits along with the number of substitutions used, or ok=false if
+// maxEdits does not allow any.
+//
+// A general search over up to k substitutions would try on the order of
+// (9n)^k candidates for n digits, but no search deeper than one edit is
+// ever needed: every position can repair any invalid number on its own (see
+// MinimalFix), so the fewest edits is always 0 or 1 and any maxEdits of 1
+// or more gives the same result. Larger values are accepted rather than
+// capped, since they cost nothing. As with MinimalFix, the repair returned
+// is the first in position order, which is not necessarily the digit that
+// was mistyped.
+func Fix(s string, maxEdits int) (corrected string, edits int, ok bool, err error) {
+    if maxEdits < 0 {
+        return "", 0, false, fmt.Errorf("maxEdits must not be negative: %d", maxEdits)
+    }
+    digits, err := stringToDigits(s)
+    if err != nil {
+        return "", 0, false, err
+    }
+    if len(digits) == 0 {
+        return "", 0, false, ErrEmptyInput
+    }
+    if validateChecksum(digits) {
+        return s, 0, true, nil
+    }
+    if maxEdits == 0 {
+        return "", 0, false, nil
+    }
+
+    pos, digit, _, _ := MinimalFix(s)
+    digits[pos] = digit
+    return digitsToString(digits), 1, true, nil
+}
+
+// NeighborsValid returns every number that differs from s in exactly one
+// digit and passes validation, ordered by position and then by digit. The
+// result holds at most 9*len(s) entries.
+//
+// A valid s has no valid neighbours, since all single-digit errors are
+// detected. For an invalid s there is exactly one valid replacement at each
+// position, so the result lists one candidate correction per position.
+func NeighborsValid(s string) ([]string, error) {
+    digits, err := stringToDigits(s)
+    if err != nil {
+        return nil, err
+    }
+    if len(digits) == 0 {
+        return nil, ErrEmptyInput
+    }
+
+    neighbors := []string{}
+    for _, f := range singleDigitFixes(digits, 0) {
+        neighbors = append(neighbors, f.apply(digits))
+    }
+    return neighbors, nil
+}
+
+// pInv holds the inverse of each permutation in p, so pInv[i][p[i][x]] == x.
+var pInv = invertPermutations(p)
+
+// invertPermutations returns the inverse of each permutation row in perms.
+func invertPermutations(perms [][]int) [][]int {
+    inverted := make([][]int, len(perms))
+    for i, row := range perms {
+        inverted[i] = make([]int, len(row))
+        for x, y := range row {
+            inverted[i][y] = x
+        }
+    }
+    return inverted
+}
+
+// CorrectionFromSyndrome returns the digit that position pos of s must hold
+// for s to pass validation, computed directly from the group structure
+// instead of trying every substitution.
+//
+// Validation multiplies the permuted digits together in the dihedral group
+// D5 and accepts the number when the product is the identity. Splitting that
+// product around pos into a left part L and a right part R, the permuted
+// digit at pos must be inverse(L) * inverse(R); undoing the position's
+// permutation yields the digit. This takes a single pass over s, which beats
+// trying 9*len(s) substitutions on long numbers. If s is already valid the
+// digit currently at pos is returned.
+func CorrectionFromSyndrome(s string, pos int) (int, error) {
+    digits, err := stringToDigits(s)
+    if err != nil {
+        return -1, err
+    }
+    if len(digits) == 0 {
+        return -1, ErrEmptyInput
+    }
+    if pos < 0 || pos >= len(digits) {
+        return -1, fmt.Errorf("position %d out of range", pos)
+    }
+
+    // Positions count from the rightmost digit, as in validateChecksum.
+    target := len(digits) - 1 - pos
+    left, right := 0, 0
+    for i := 0; i < len(digits); i++ {
+        value := p[i%8][digits[len(digits)-1-i]]
+        if i < target {
+            left = d[left][value]
+        } else if i > target {
+            right = d[right][value]
+        }
+    }
+
+    needed := d[inv[left]][inv[right]]
+    return pInv[target%8][needed], nil
+}
+
+// RecoverDigit returns the digit that belongs at position in s, a number
+// with its check digit where the character at position is unknown, such as
+// a digit OCR could not read. That character may be anything, including a
+// placeholder like '?'; every other character must be a digit. Exactly one
+// value makes the number valid, so ok is false only if position is out of
+// range or the rest of s is not all digits.
+func RecoverDigit(s string, position int) (int, bool) {
+    chars := []rune(s)
+    if position < 0 || position >= len(chars) {
+        return -1, false
+    }
+
+    chars[position] = '0'
+    digit, err := CorrectionFromSyndrome(string(chars), position)
+    if err != nil {
+        return -1, false
+    }
+    return digit, true
+}
+
+// TranspositionFix finds an adjacent pair of digits whose swap turns an
+// invalid number into a valid one, returning the index i of the first digit
+// of the pair (digits i and i+1 are swapped). Pairs are tried from the lowest
+// index upwards and the first match is returned. It returns ok=false if s is
+// already valid or no adjacent swap makes it valid.
+func TranspositionFix(s string) (i int, ok bool, err error) {
+    digits, err := stringToDigits(s)
+    if err != nil {
+        return -1, false, err
+    }
+    if len(digits) == 0 {
+        return -1, false, ErrEmptyInput
+    }
+    if validateChecksum(digits) {
+        return -1, false, nil
+    }
+
+    for i := 0; i < len(digits)-1; i++ {
+        if digits[i] == digits[i+1] {
+            continue
+        }
+        digits[i], digits[i+1] = digits[i+1], digits[i]
+        valid := validateChecksum(digits)
+        digits[i], digits[i+1] = digits[i+1], digits[i]
+        if valid {
+            return i, true, nil
+        }
+    }
+    return -1, false, nil
+}
+
+// SuggestionKind identifies the edit behind a Suggestion.
+type SuggestionKind int
+
+const (
+    // SingleDigit means one digit was replaced.
+    SingleDigit SuggestionKind = iota
+    // Transposition means two adjacent digits were swapped.
+    Transposition
+)
+
+// String returns the name of the kind.
+func (k SuggestionKind) String() string {
+    switch k {
+    case SingleDigit:
+        return "single-digit"
+    case Transposition:
+        return "transposition"
+    default:
+        return fmt.Sprintf("SuggestionKind(%d)", int(k))
+    }
+}
+
+// Suggestion is a single edit that turns an invalid number into a valid one.
+type Suggestion struct {
+    // Kind is the type of edit.
+    Kind SuggestionKind
+    // Result is the valid number after the edit.
+    Result string
+    // Position is the index of the replaced digit, or of the first digit of
+    // the swapped pair.
+    Position int
+}
+
+// Suggest returns every single-digit substitution and adjacent transposition
+// that makes s valid, for "did you mean" prompts. Transpositions come first,
+// ordered by position: they only exist for specific inputs and so point
+// strongly at what the user typed, whereas an invalid number always has one
+// valid substitution per position. Substitutions follow, ordered by position.
+//
+// An already valid s, or one that is empty or contains non-digit
+// characters, yields an empty slice.
+func Suggest(s string) []Suggestion {
+    suggestions := []Suggestion{}
+    digits, err := stringToDigits(s)
+    if err != nil || len(digits) == 0 || validateChecksum(digits) {
+        return suggestions
+    }
+
+    for i := 0; i < len(digits)-1; i++ {
+        if digits[i] == digits[i+1] {
+            continue
+        }
+        digits[i], digits[i+1] = digits[i+1], digits[i]
+        if validateChecksum(digits) {
+            suggestions = append(suggestions, Suggestion{
+                Kind:     Transposition,
+                Result:   digitsToString(digits),
+                Position: i,
+            })
+        }
+        digits[i], digits[i+1] = digits[i+1], digits[i]
+    }
+
+    for _, f := range singleDigitFixes(digits, 0) {
+        suggestions = append(suggestions, Suggestion{
+            Kind:     SingleDigit,
+            Result:   f.apply(digits),
+            Position: f.pos,
+        })
+    }
+    return suggestions
+}
+
+// SuggestCorrections returns every number reachable from s by one
+// single-digit substitution or one adjacent transposition that passes
+// validation, in the order Suggest uses: transpositions first, then
+// substitutions, each by position. An already valid s gives an empty slice.
+//
+// Expect several results for an invalid s, not one. Verhoeff detects every
+// single-digit error, but that also means each position has exactly one
+// digit that repairs the number, so there are always len(s) substitutions
+// on offer; a transposition, when present, is usually the better hint.
+func SuggestCorrections(s string) ([]string, error) {
+    digits, err := stringToDigits(s)
+    if err != nil {
+        return nil, err
+    }
+    if len(digits) == 0 {
+        return nil, ErrEmptyInput
+    }
+
+    corrections := []string{}
+    for _, suggestion := range Suggest(s) {
+        corrections = append(corrections, suggestion.Result)
+    }
+    return corrections, nil
+}
+
+// ErrorDiagnosis describes the most likely error in a number that failed
+// validation.
+type ErrorDiagnosis struct {
+    // Kind is the type of the most likely error.
+    Kind SuggestionKind
+    // Positions holds the index of the wrong digit, or the indices of both
+    // digits of a transposed pair.
+    Positions []int
+    // Candidates lists every single edit that makes the number valid, most
+    // likely first, in the order Suggest returns them.
+    Candidates []Suggestion
+}
+
+// ValidateWithDiagnosis validates s and, if it is invalid, diagnoses which
+// digits look wrong, for pointing users at the mistake. It returns a nil
+// diagnosis for a valid s.
+//
+// The diagnosis is built from Suggest, trying every adjacent swap and every
+// single-digit substitution. A transposition that makes s valid is reported
+// as the most likely error when there is one, since such swaps exist only
+// for specific inputs. Otherwise the first substitution is reported; every
+// position has exactly one valid substitution, so it is only a guess, and
+// Candidates lists the others.
+func ValidateWithDiagnosis(s string) (bool, *ErrorDiagnosis, error) {
+    valid, err := ValidateString(s)
+    if err != nil || valid {
+        return valid, nil, err
+    }
+
+    candidates := Suggest(s)
+    first := candidates[0]
+    diagnosis := &ErrorDiagnosis{
+        Kind:       first.Kind,
+        Positions:  []int{first.Position},
+        Candidates: candidates,
+    }
+    if first.Kind == Transposition {
+        diagnosis.Positions = append(diagnosis.Positions, first.Position+1)
+    }
+    return false, diagnosis, nil
+}
+
+// DefaultOCRConfusables lists the digit pairs ValidateOCRTolerant treats as
+// likely OCR misreads: 0/8, 1/7 and 5/6. Each pair is tried in both
+// directions.
+var DefaultOCRConfusables = [][2]int{{0, 8}, {1, 7}, {5, 6}}
+
+// ValidateOCRTolerant validates s and, if it fails, looks for a single
+// OCR-confusable substitution from DefaultOCRConfusables that makes it
+// valid. It returns true with the corrected string when s is valid as read
+// or can be repaired that way, and false with an empty string otherwise.
+func ValidateOCRTolerant(s string) (bool, string, error) {
+    return ValidateOCRTolerantWith(s, DefaultOCRConfusables)
+}
+
+// ValidateOCRTolerantWith works like ValidateOCRTolerant with a caller
+// supplied set of confusable pairs. Positions are tried from left to right
+// and, at each position, pairs in the order given, so the first valid
+// reading found is deterministic. A valid s is returned unchanged. Pair
+// values outside 0-9 are rejected with an error.
+func ValidateOCRTolerantWith(s string, pairs [][2]int) (bool, string, error) {
+    for _, pair := range pairs {
+        for _, v := range pair {
+            if v < 0 || v > 9 {
+                return false, "", fmt.Errorf("%w in confusable pair %v", ErrInvalidDigit, pair)
+            }
+        }
+    }
+
+    digits, err := stringToDigits(s)
+    if err != nil {
+        return false, "", err
+    }
+    if len(digits) == 0 {
+        return false, "", ErrEmptyInput
+    }
+    if validateChecksum(digits) {
+        return true, s, nil
+    }
+
+    for i, original := range digits {
+        for _, pair := range pairs {
+            var replacement int
+            switch original {
+            case pair[0]:
+                replacement = pair[1]
+            case pair[1]:
+                replacement = pair[0]
+            default:
+                continue
+            }
+            digits[i] = replacement
+            if validateChecksum(digits) {
+                return true, digitsToString(digits), nil
+            }
+        }
+        digits[i] = original
+    }
+    return false, "", nil
+}
+
+// DigitProb is one candidate reading of a digit, with the probability an
+// OCR engine assigns to it.
+type DigitProb struct {
+    Digit int
+    Prob  float64
+}
+
+// ValidateWithAlternatives picks the most probable reading of a number from
+// per-position OCR alternatives that passes validation. alts[i] lists the
+// candidates for the i-th digit from the left; the probability of a reading
+// is the product of the chosen candidates' probabilities. It returns the
+// best valid reading with valid true or, if no combination validates, the
+// most probable reading overall with valid false. Ties go to the candidate
+// listed first.
+//
+// The search is exact and needs no pruning: validation only depends on the
+// running product in D5, which takes ten values, so dynamic programming
+// over that product keeps the cost at O(10 * total candidates) however long
+// the number is. Candidates with probability 0 are never chosen; digits
+// outside 0-9 and probabilities outside [0, 1] are rejected.
+func ValidateWithAlternatives(alts [][]DigitProb) (best string, valid bool, err error) {
+    if len(alts) == 0 {
+        return "", false, ErrEmptyInput
+    }
+    for i, candidates := range alts {
+        if len(candidates) == 0 {
+            return "", false, fmt.Errorf("position %d has no candidates", i)
+        }
+        for _, c := range candidates {
+            if c.Digit < 0 || c.Digit > 9 {
+                return "", false, fmt.Errorf("position %d: invalid digit: %d", i, c.Digit)
+            }
+            if !(c.Prob >= 0 && c.Prob <= 1) {
+                return "", false, fmt.Errorf("position %d: invalid probability: %v", i, c.Prob)
+            }
+        }
+    }
+
+    // choice records, for each position and the product reached after it,
+    // the product before it and the candidate that led there.
+    type choice struct{ prev, candidate int }
+    n := len(alts)
+    back := make([][10]choice, n)
+    var score [10]float64
+    for c := range score {
+        score[c] = math.Inf(-1)
+    }
+    score[0] = 0
+
+    // Fold from the rightmost digit, as validateChecksum does, scoring in
+    // log space so long numbers do not underflow.
+    for j := n - 1; j >= 0; j-- {
+        var next [10]float64
+        for c := range next {
+            next[c] = math.Inf(-1)
+        }
+        for c := 0; c < 10; c++ {
+            if math.IsInf(score[c], -1) {
+                continue
+            }
+            for k, candidate := range alts[j] {
+                if candidate.Prob == 0 {
+                    continue
+                }
+                product := d[c][p[(n-1-j)%8][candidate.Digit]]
+                s := score[c] + math.Log(candidate.Prob)
+                if s > next[product] {
+                    next[product] = s
+                    back[j][product] = choice{prev: c, candidate: k}
+                }
+            }
+        }
+        score = next
+    }
+
+    digits := make([]int, n)
+    if math.IsInf(score[0], -1) {
+        for j, candidates := range alts {
+            top := 0
+            for k, candidate := range candidates {
+                if candidate.Prob > candidates[top].Prob {
+                    top = k
+                }
+            }
+            digits[j] = candidates[top].Digit
+        }
+        return digitsToString(digits), false, nil
+    }
+
+    c := 0
+    for j := 0; j < n; j++ {
+        step := back[j][c]
+        digits[j] = alts[j][step.candidate].Digit
+        c = step.prev
+    }
+    return digitsToString(digits), true, nil
+}
@@ -0,0 +1,543 @@
+// FilePath: correct_test.go
+
+package verhoeff
+
+import (
+    "errors"
+    "math"
+    "math/rand"
+    "reflect"
+    "testing"
+)
+
+func TestMinimalFix(t *testing.T) {
+    tests := []struct {
+        name          string
+        input         string
+        expectedPos   int
+        expectedDigit int
+        expectedOK    bool
+        hasError      bool
+    }{
+        {"Already valid", "2363", -1, -1, false, false},
+        {"Wrong check digit", "2364", 0, 4, true, false},
+        {"Longer number", "123450", 0, 6, true, false},
+        {"Seven digits", "1428571", 0, 4, true, false},
+        {"Empty input", "", -1, -1, false, true},
+        {"Non-digit input", "12a4", -1, -1, false, true},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            pos, digit, ok, err := MinimalFix(tt.input)
+
+            if (err != nil) != tt.hasError {
+                t.Errorf("MinimalFix() error = %v, wantErr %v",
+                    err, tt.hasError)
+                return
+            }
+
+            if tt.hasError {
+                return
+            }
+
+            if pos != tt.expectedPos || digit != tt.expectedDigit ||
+                ok != tt.expectedOK {
+                t.Errorf("MinimalFix() = (%d, %d, %v), want (%d, %d, %v)",
+                    pos, digit, ok,
+                    tt.expectedPos, tt.expectedDigit, tt.expectedOK)
+            }
+
+            if ok {
+                fixed := tt.input[:pos] + string(rune('0'+digit)) +
+                    tt.input[pos+1:]
+                valid, err := ValidateString(fixed)
+                if err != nil || !valid {
+                    t.Errorf("MinimalFix() result %s is not valid", fixed)
+                }
+            }
+        })
+    }
+}
+
+func TestFix(t *testing.T) {
+    tests := []struct {
+        name              string
+        input             string
+        maxEdits          int
+        expectedCorrected string
+        expectedEdits     int
+        expectedOK        bool
+        hasError          bool
+    }{
+        {"Already valid", "2363", 1, "2363", 0, true, false},
+        {"Already valid no edits allowed", "2363", 0, "2363", 0, true, false},
+        {"One edit", "2364", 1, "4364", 1, true, false},
+        {"Longer number", "123450", 1, "623450", 1, true, false},
+        {"More edits allowed", "123450", 3, "623450", 1, true, false},
+        {"No edits allowed", "2364", 0, "", 0, false, false},
+        {"Negative maxEdits", "2364", -1, "", 0, false, true},
+        {"Empty input", "", 1, "", 0, false, true},
+        {"Non-digit input", "12a4", 1, "", 0, false, true},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            corrected, edits, ok, err := Fix(tt.input, tt.maxEdits)
+
+            if (err != nil) != tt.hasError {
+                t.Errorf("Fix() error = %v, wantErr %v", err, tt.hasError)
+                return
+            }
+
+            if corrected != tt.expectedCorrected || edits != tt.expectedEdits ||
+                ok != tt.expectedOK {
+                t.Errorf("Fix() = (%q, %d, %v), want (%q, %d, %v)",
+                    corrected, edits, ok,
+                    tt.expectedCorrected, tt.expectedEdits, tt.expectedOK)
+            }
+        })
+    }
+}
+
+func TestNeighborsValid(t *testing.T) {
+    tests := []struct {
+        name     string
+        input    string
+        expected []string
+        hasError bool
+    }{
+        {"Valid input", "2363", []string{}, false},
+        {"Invalid input", "2364", []string{"4364", "2964", "2344", "2363"}, false},
+        {"Empty input", "", nil, true},
+        {"Non-digit input", "23a4", nil, true},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            got, err := NeighborsValid(tt.input)
+
+            if (err != nil) != tt.hasError {
+                t.Errorf("NeighborsValid() error = %v, wantErr %v",
+                    err, tt.hasError)
+                return
+            }
+
+            if tt.hasError {
+                return
+            }
+
+            if len(got) != len(tt.expected) {
+                t.Errorf("NeighborsValid() = %v, want %v", got, tt.expected)
+                return
+            }
+
+            for i := range got {
+                if got[i] != tt.expected[i] {
+                    t.Errorf("NeighborsValid() = %v, want %v",
+                        got, tt.expected)
+                    return
+                }
+            }
+        })
+    }
+
+    t.Run("One neighbour per position", func(t *testing.T) {
+        got, err := NeighborsValid("1234567891")
+        if err != nil {
+            t.Fatalf("NeighborsValid() error = %v", err)
+        }
+        if len(got) != 10 {
+            t.Errorf("NeighborsValid() returned %d neighbours, want 10",
+                len(got))
+        }
+    })
+}
+
+func TestCorrectionFromSyndrome(t *testing.T) {
+    tests := []struct {
+        name     string
+        input    string
+        pos      int
+        expected int
+        hasError bool
+    }{
+        {"Fix check digit", "2364", 3, 3, false},
+        {"Fix first digit", "2364", 0, 4, false},
+        {"Fix middle digit", "2364", 1, 9, false},
+        {"Already valid", "2363", 2, 6, false},
+        {"Position out of range", "2364", 4, -1, true},
+        {"Negative position", "2364", -1, -1, true},
+        {"Empty input", "", 0, -1, true},
+        {"Non-digit input", "23a4", 0, -1, true},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            got, err := CorrectionFromSyndrome(tt.input, tt.pos)
+
+            if (err != nil) != tt.hasError {
+                t.Errorf("CorrectionFromSyndrome() error = %v, wantErr %v",
+                    err, tt.hasError)
+                return
+            }
+
+            if got != tt.expected {
+                t.Errorf("CorrectionFromSyndrome() = %v, want %v",
+                    got, tt.expected)
+            }
+        })
+    }
+
+    t.Run("Agrees with NeighborsValid", func(t *testing.T) {
+        input := "98765432101234567890"
+        neighbors, err := NeighborsValid(input)
+        if err != nil {
+            t.Fatalf("NeighborsValid() error = %v", err)
+        }
+        for pos := range input {
+            digit, err := CorrectionFromSyndrome(input, pos)
+            if err != nil {
+                t.Fatalf("CorrectionFromSyndrome() error = %v", err)
+            }
+            want := neighbors[pos][pos]
+            if byte('0'+digit) != want {
+                t.Errorf("position %d: got %d, want %c", pos, digit, want)
+            }
+        }
+    })
+}
+
+func TestRecoverDigit(t *testing.T) {
+    tests := []struct {
+        name          string
+        input         string
+        pos           int
+        expectedDigit int
+        expectedOK    bool
+    }{
+        {"Unknown middle digit", "23?3", 2, 6, true},
+        {"Unknown first digit", "?363", 0, 2, true},
+        {"Unknown check digit", "236?", 3, 3, true},
+        {"Longer number", "1234?1", 4, 5, true},
+        {"Digit at position ignored", "2393", 2, 6, true},
+        {"Multi-byte placeholder", "23\u25a13", 2, 6, true},
+        {"Position out of range", "23?3", 4, -1, false},
+        {"Negative position", "23?3", -1, -1, false},
+        {"Non-digit elsewhere", "2a?3", 2, -1, false},
+        {"Empty input", "", 0, -1, false},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            digit, ok := RecoverDigit(tt.input, tt.pos)
+            if digit != tt.expectedDigit || ok != tt.expectedOK {
+                t.Errorf("RecoverDigit() = (%d, %v), want (%d, %v)",
+                    digit, ok, tt.expectedDigit, tt.expectedOK)
+            }
+        })
+    }
+}
+
+func TestTranspositionFix(t *testing.T) {
+    tests := []struct {
+        name          string
+        input         string
+        expectedIndex int
+        expectedOK    bool
+        hasError      bool
+    }{
+        {"Swapped middle digits", "2633", 1, true, false},
+        {"Swapped check digit", "123415", 4, true, false},
+        {"Several fixes picks lowest", "1243510", 0, true, false},
+        {"Already valid", "2363", -1, false, false},
+        {"No swap fixes it", "21345", -1, false, false},
+        {"Empty input", "", -1, false, true},
+        {"Non-digit input", "26a3", -1, false, true},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            i, ok, err := TranspositionFix(tt.input)
+
+            if (err != nil) != tt.hasError {
+                t.Errorf("TranspositionFix() error = %v, wantErr %v",
+                    err, tt.hasError)
+                return
+            }
+
+            if i != tt.expectedIndex || ok != tt.expectedOK {
+                t.Errorf("TranspositionFix() = (%d, %v), want (%d, %v)",
+                    i, ok, tt.expectedIndex, tt.expectedOK)
+            }
+        })
+    }
+}
+
+func TestSuggest(t *testing.T) {
+    tests := []struct {
+        name     string
+        input    string
+        expected []Suggestion
+    }{
+        {
+            "Transposition ranked first", "2633",
+            []Suggestion{
+                {Transposition, "2363", 1},
+                {SingleDigit, "8633", 0},
+                {SingleDigit, "2033", 1},
+                {SingleDigit, "2623", 2},
+                {SingleDigit, "2634", 3},
+            },
+        },
+        {"Already valid", "2363", []Suggestion{}},
+        {"Empty input", "", []Suggestion{}},
+        {"Non-digit input", "26a3", []Suggestion{}},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            got := Suggest(tt.input)
+
+            if len(got) != len(tt.expected) {
+                t.Errorf("Suggest() = %v, want %v", got, tt.expected)
+                return
+            }
+
+            for i := range got {
+                if got[i] != tt.expected[i] {
+                    t.Errorf("Suggest()[%d] = %+v, want %+v",
+                        i, got[i], tt.expected[i])
+                }
+            }
+        })
+    }
+}
+
+func TestSuggestionKindString(t *testing.T) {
+    if SingleDigit.String() != "single-digit" ||
+        Transposition.String() != "transposition" ||
+        SuggestionKind(9).String() != "SuggestionKind(9)" {
+        t.Errorf("SuggestionKind.String() returned unexpected names")
+    }
+}
+
+func TestValidateOCRTolerant(t *testing.T) {
+    tests := []struct {
+        name              string
+        input             string
+        expected          bool
+        expectedCorrected string
+        hasError          bool
+    }{
+        {"Valid as read", "123451", true, "123451", false},
+        {"7 misread for 1", "723451", true, "123451", false},
+        {"6 misread for 5", "123461", true, "123451", false},
+        {"First correction wins", "128451", true, "728451", false},
+        {"No confusable fix", "2364", false, "", false},
+        {"Empty string", "", false, "", true},
+        {"Non-digit", "12a451", false, "", true},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            got, corrected, err := ValidateOCRTolerant(tt.input)
+
+            if (err != nil) != tt.hasError {
+                t.Errorf("ValidateOCRTolerant() error = %v, wantErr %v",
+                    err, tt.hasError)
+                return
+            }
+
+            if got != tt.expected || corrected != tt.expectedCorrected {
+                t.Errorf("ValidateOCRTolerant() = (%v, %q), want (%v, %q)",
+                    got, corrected, tt.expected, tt.expectedCorrected)
+            }
+        })
+    }
+
+    t.Run("Custom pairs", func(t *testing.T) {
+        pairs := [][2]int{{2, 9}}
+        got, corrected, err := ValidateOCRTolerantWith("9363", pairs)
+        if err != nil || !got || corrected != "2363" {
+            t.Errorf("ValidateOCRTolerantWith() = (%v, %q, %v), want (true, \"2363\", nil)",
+                got, corrected, err)
+        }
+
+        got, corrected, _ = ValidateOCRTolerantWith("723451", pairs)
+        if got || corrected != "" {
+            t.Errorf("ValidateOCRTolerantWith() = (%v, %q), want (false, \"\")",
+                got, corrected)
+        }
+    })
+
+    t.Run("Pairs out of range", func(t *testing.T) {
+        for _, pairs := range [][][2]int{{{1, -3}}, {{10, 2}}, {{0, 8}, {5, 12}}} {
+            got, corrected, err := ValidateOCRTolerantWith("12345", pairs)
+            if !errors.Is(err, ErrInvalidDigit) || got || corrected != "" {
+                t.Errorf("ValidateOCRTolerantWith(%v) = (%v, %q, %v), want ErrInvalidDigit",
+                    pairs, got, corrected, err)
+            }
+        }
+    })
+}
+
+func TestSuggestCorrections(t *testing.T) {
+    tests := []struct {
+        name     string
+        input    string
+        expected []string
+        hasError bool
+    }{
+        {"Already valid", "2363", []string{}, false},
+        {"Transposition first", "2633", []string{"2363", "8633", "2033", "2623", "2634"}, false},
+        {"Substitutions only", "2365", []string{"1365", "2165", "2385", "2363"}, false},
+        {"Empty input", "", nil, true},
+        {"Non-digit input", "12a4", nil, true},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            got, err := SuggestCorrections(tt.input)
+
+            if (err != nil) != tt.hasError {
+                t.Errorf("SuggestCorrections() error = %v, wantErr %v",
+                    err, tt.hasError)
+                return
+            }
+
+            if !reflect.DeepEqual(got, tt.expected) {
+                t.Errorf("SuggestCorrections() = %v, want %v", got, tt.expected)
+            }
+        })
+    }
+}
+
+func TestValidateWithDiagnosis(t *testing.T) {
+    tests := []struct {
+        name               string
+        input              string
+        expectedValid      bool
+        expectedKind       SuggestionKind
+        expectedPositions  []int
+        expectedCandidates int
+        hasError           bool
+    }{
+        {"Valid number", "2363", true, 0, nil, 0, false},
+        {"Transposed pair", "2633", false, Transposition, []int{1, 2}, 5, false},
+        {"Two transpositions", "123450", false, Transposition, []int{0, 1}, 8, false},
+        {"Substitution only", "2365", false, SingleDigit, []int{0}, 4, false},
+        {"Empty input", "", false, 0, nil, 0, true},
+        {"Non-digit input", "12a4", false, 0, nil, 0, true},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            valid, diagnosis, err := ValidateWithDiagnosis(tt.input)
+
+            if (err != nil) != tt.hasError {
+                t.Errorf("ValidateWithDiagnosis() error = %v, wantErr %v",
+                    err, tt.hasError)
+                return
+            }
+
+            if valid != tt.expectedValid {
+                t.Errorf("ValidateWithDiagnosis() valid = %v, want %v", valid, tt.expectedValid)
+            }
+            if tt.expectedPositions == nil {
+                if diagnosis != nil {
+                    t.Errorf("ValidateWithDiagnosis() diagnosis = %+v, want nil", diagnosis)
+                }
+                return
+            }
+
+            if diagnosis.Kind != tt.expectedKind ||
+                !reflect.DeepEqual(diagnosis.Positions, tt.expectedPositions) ||
+                len(diagnosis.Candidates) != tt.expectedCandidates {
+                t.Errorf("ValidateWithDiagnosis() = (%v, %v, %d candidates), want (%v, %v, %d)",
+                    diagnosis.Kind, diagnosis.Positions, len(diagnosis.Candidates),
+                    tt.expectedKind, tt.expectedPositions, tt.expectedCandidates)
+            }
+        })
+    }
+}
+
+func TestValidateWithAlternatives(t *testing.T) {
+    tests := []struct {
+        name          string
+        alts          [][]DigitProb
+        expectedBest  string
+        expectedValid bool
+        hasError      bool
+    }{
+        {"Most probable valid reading", [][]DigitProb{
+            {{2, 0.9}, {7, 0.1}}, {{3, 0.5}, {8, 0.5}}, {{6, 0.6}, {5, 0.4}}, {{8, 0.7}, {3, 0.3}},
+        }, "2858", true, false},
+        {"Certain valid reading", [][]DigitProb{{{2, 1}}, {{3, 1}}, {{6, 1}}, {{3, 1}}}, "2363", true, false},
+        {"Zero probability never chosen", [][]DigitProb{
+            {{2, 1}}, {{3, 1}}, {{6, 1}}, {{4, 0.9}, {3, 0}},
+        }, "2364", false, false},
+        {"No valid reading", [][]DigitProb{
+            {{2, 1}}, {{3, 0.4}, {8, 0.6}}, {{6, 1}}, {{4, 1}},
+        }, "2864", false, false},
+        {"Empty input", nil, "", false, true},
+        {"Position without candidates", [][]DigitProb{{{2, 1}}, {}}, "", false, true},
+        {"Invalid digit", [][]DigitProb{{{10, 1}}}, "", false, true},
+        {"Probability above one", [][]DigitProb{{{1, 1.5}}}, "", false, true},
+        {"Probability NaN", [][]DigitProb{{{1, math.NaN()}}}, "", false, true},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            best, valid, err := ValidateWithAlternatives(tt.alts)
+
+            if (err != nil) != tt.hasError {
+                t.Errorf("ValidateWithAlternatives() error = %v, wantErr %v",
+                    err, tt.hasError)
+                return
+            }
+
+            if best != tt.expectedBest || valid != tt.expectedValid {
+                t.Errorf("ValidateWithAlternatives() = (%q, %v), want (%q, %v)",
+                    best, valid, tt.expectedBest, tt.expectedValid)
+            }
+        })
+    }
+
+    t.Run("Matches exhaustive search", func(t *testing.T) {
+        rng := rand.New(rand.NewSource(17))
+        for n := 0; n < 200; n++ {
+            alts := make([][]DigitProb, 1+rng.Intn(5))
+            for i := range alts {
+                alts[i] = make([]DigitProb, 1+rng.Intn(3))
+                for k := range alts[i] {
+                    alts[i][k] = DigitProb{Digit: rng.Intn(10), Prob: rng.Float64()}
+                }
+            }
+
+            // Enumerate every reading, keeping the most probable valid one.
+            want, wantProb := "", -1.0
+            var walk func(i int, digits []int, prob float64)
+            walk = func(i int, digits []int, prob float64) {
+                if i == len(alts) {
+                    if validateChecksum(digits) && prob > wantProb {
+                        want, wantProb = digitsToString(digits), prob
+                    }
+                    return
+                }
+                for _, c := range alts[i] {
+                    walk(i+1, append(digits, c.Digit), prob*c.Prob)
+                }
+            }
+            walk(0, nil, 1)
+
+            best, valid, err := ValidateWithAlternatives(alts)
+            if err != nil {
+                t.Fatalf("ValidateWithAlternatives() error = %v", err)
+            }
+            if valid != (want != "") || (valid && best != want) {
+                t.Fatalf("ValidateWithAlternatives(%v) = (%q, %v), want %q", alts, best, valid, want)
+            }
+        }
+    })
+}
@@ -0,0 +1,70 @@
+// FilePath: damm.go
+
+package verhoeff
+
+import (
+    "errors"
+    "strconv"
+)
+
+// dammTable is the totally anti-symmetric quasigroup of order 10 used by the
+// Damm algorithm.
+var dammTable = [][]int{
+    {0, 3, 1, 7, 5, 9, 8, 6, 4, 2},
+    {7, 0, 9, 2, 1, 5, 4, 8, 6, 3},
+    {4, 2, 0, 6, 8, 7, 1, 3, 5, 9},
+    {1, 7, 5, 0, 9, 8, 3, 4, 2, 6},
+    {6, 1, 2, 3, 0, 4, 5, 9, 7, 8},
+    {3, 6, 7, 4, 2, 0, 9, 5, 8, 1},
+    {5, 8, 6, 9, 7, 2, 0, 1, 3, 4},
+    {8, 9, 4, 5, 3, 6, 2, 0, 1, 7},
+    {9, 4, 3, 8, 6, 1, 7, 2, 0, 5},
+    {2, 5, 8, 1, 4, 3, 6, 7, 9, 0},
+}
+
+// dammInterim runs the Damm recurrence over digits left to right. For a
+// payload the result is its Damm check digit; for a number ending in its
+// check digit the result is 0.
+func dammInterim(digits []int) int {
+    c := 0
+    for _, digit := range digits {
+        c = dammTable[c][digit]
+    }
+    return c
+}
+
+// GenerateHybrid appends two check digits to s for a vendor format that
+// combines both algorithms: first the Verhoeff check digit over s, then the
+// Damm check digit over s followed by that Verhoeff digit. For example
+// "12345" becomes "1234515".
+func GenerateHybrid(s string) (string, error) {
+    digits, err := stringToDigits(s)
+    if err != nil {
+        return "", err
+    }
+
+    verhoeffCheck := calculateChecksum(digits)
+    digits = append(digits, verhoeffCheck)
+    dammCheck := dammInterim(digits)
+    return s + strconv.Itoa(verhoeffCheck) + strconv.Itoa(dammCheck), nil
+}
+
+// ValidateHybrid checks a number produced by GenerateHybrid: the
+// second-to-last digit must be the Verhoeff check digit over the payload
+// before it, and the last digit the Damm check digit over the payload and
+// the Verhoeff digit. Both must hold.
+func ValidateHybrid(s string) (bool, error) {
+    digits, err := stringToDigits(s)
+    if err != nil {
+        return false, err
+    }
+    if len(digits) < 2 {
+        return false, errors.New("hybrid numbers need at least two check digits")
+    }
+
+    withVerhoeff := digits[:len(digits)-1]
+    if !validateChecksum(withVerhoeff) {
+        return false, nil
+    }
+    return dammInterim(digits) == 0, nil
+}
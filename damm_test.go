@@ -0,0 +1,89 @@
+// FilePath: damm_test.go
+
+package verhoeff
+
+import (
+    "testing"
+)
+
+func TestDammInterim(t *testing.T) {
+    if got := dammInterim([]int{5, 7, 2}); got != 4 {
+        t.Errorf("dammInterim(572) = %d, want 4", got)
+    }
+    if got := dammInterim([]int{5, 7, 2, 4}); got != 0 {
+        t.Errorf("dammInterim(5724) = %d, want 0", got)
+    }
+}
+
+func TestGenerateHybrid(t *testing.T) {
+    tests := []struct {
+        name     string
+        input    string
+        expected string
+        hasError bool
+    }{
+        {"Five digits", "12345", "1234515", false},
+        {"Three digits", "236", "23632", false},
+        {"Empty payload", "", "00", false},
+        {"Non-digit", "12a45", "", true},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            got, err := GenerateHybrid(tt.input)
+
+            if (err != nil) != tt.hasError {
+                t.Errorf("GenerateHybrid() error = %v, wantErr %v",
+                    err, tt.hasError)
+                return
+            }
+
+            if got != tt.expected {
+                t.Errorf("GenerateHybrid() = %v, want %v", got, tt.expected)
+            }
+
+            if tt.hasError {
+                return
+            }
+
+            valid, err := ValidateHybrid(got)
+            if err != nil || !valid {
+                t.Errorf("ValidateHybrid(%q) = %v, %v, want true", got, valid, err)
+            }
+        })
+    }
+}
+
+func TestValidateHybrid(t *testing.T) {
+    tests := []struct {
+        name     string
+        input    string
+        expected bool
+        hasError bool
+    }{
+        {"Valid", "1234515", true, false},
+        {"Wrong Damm digit", "1234516", false, false},
+        {"Wrong Verhoeff digit", "1234525", false, false},
+        {"Payload typo", "1224515", false, false},
+        {"Check digits swapped", "1234551", false, false},
+        {"Verhoeff only", "123451", false, false},
+        {"Single digit", "0", false, true},
+        {"Non-digit", "12a4515", false, true},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            got, err := ValidateHybrid(tt.input)
+
+            if (err != nil) != tt.hasError {
+                t.Errorf("ValidateHybrid() error = %v, wantErr %v",
+                    err, tt.hasError)
+                return
+            }
+
+            if got != tt.expected {
+                t.Errorf("ValidateHybrid() = %v, want %v", got, tt.expected)
+            }
+        })
+    }
+}
@@ -0,0 +1,78 @@
+// FilePath: diag.go
+
+package verhoeff
+
+import (
+    "fmt"
+    "unicode"
+)
+
+// DiagCheck is the outcome of one check performed by ValidateVerbose.
+type DiagCheck struct {
+    // Name identifies the check: "non-empty", "all-digits" or "checksum".
+    Name string
+    // Passed is true if the check succeeded.
+    Passed bool
+    // Detail is a human-readable explanation of the outcome.
+    Detail string
+}
+
+// DiagReport is the full breakdown of a validation produced by
+// ValidateVerbose.
+type DiagReport struct {
+    // Checks lists every check in the order performed. Checks that could
+    // not run because an earlier one failed are included as failed.
+    Checks []DiagCheck
+    // Valid is true if every check passed.
+    Valid bool
+    // FirstBadIndex is the byte offset of the first non-digit character,
+    // or -1 if there is none.
+    FirstBadIndex int
+    // ExpectedCheck is the checksum digit the payload calls for, or -1 if
+    // it could not be calculated.
+    ExpectedCheck int
+}
+
+// ValidateVerbose validates a number of any length and reports the outcome
+// of each check, non-empty, all-digits and checksum, rather than just the
+// first failure. It is meant for diagnostic tools; malformed input is
+// described in the report, and an error is only returned for empty input,
+// alongside a report saying so.
+func ValidateVerbose(s string) (DiagReport, error) {
+    report := DiagReport{FirstBadIndex: -1, ExpectedCheck: -1}
+
+    if s == "" {
+        report.Checks = []DiagCheck{
+            {"non-empty", false, "input is empty"},
+            {"all-digits", false, "not run: input is empty"},
+            {"checksum", false, "not run: input is empty"},
+        }
+        return report, ErrEmptyInput
+    }
+    report.Checks = append(report.Checks,
+        DiagCheck{"non-empty", true, fmt.Sprintf("%d bytes", len(s))})
+
+    for i, r := range s {
+        if !unicode.IsDigit(r) {
+            report.FirstBadIndex = i
+            report.Checks = append(report.Checks,
+                DiagCheck{"all-digits", false,
+                    fmt.Sprintf("non-digit %q at index %d", r, i)},
+                DiagCheck{"checksum", false, "not run: input contains non-digits"})
+            return report, nil
+        }
+    }
+    report.Checks = append(report.Checks,
+        DiagCheck{"all-digits", true, "every character is a digit"})
+
+    digits, err := stringToDigits(s)
+    if err != nil {
+        return report, err
+    }
+    check := digits[len(digits)-1]
+    report.ExpectedCheck = calculateChecksum(digits[:len(digits)-1])
+    report.Valid = check == report.ExpectedCheck
+    report.Checks = append(report.Checks, DiagCheck{"checksum", report.Valid,
+        fmt.Sprintf("expected check digit %d, got %d", report.ExpectedCheck, check)})
+    return report, nil
+}
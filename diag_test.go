@@ -0,0 +1,66 @@
+// FilePath: diag_test.go
+
+package verhoeff
+
+import (
+    "errors"
+    "testing"
+)
+
+func TestValidateVerbose(t *testing.T) {
+    tests := []struct {
+        name          string
+        input         string
+        expectedValid bool
+        expectedPass  []bool
+        expectedBad   int
+        expectedCheck int
+        hasError      bool
+    }{
+        {"Valid number", "123451", true, []bool{true, true, true}, -1, 1, false},
+        {"Wrong check digit", "123459", false, []bool{true, true, false}, -1, 1, false},
+        {"Single digit", "0", true, []bool{true, true, true}, -1, 0, false},
+        {"Letter", "12a451", false, []bool{true, false, false}, 2, -1, false},
+        {"Empty string", "", false, []bool{false, false, false}, -1, -1, true},
+    }
+
+    names := []string{"non-empty", "all-digits", "checksum"}
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            got, err := ValidateVerbose(tt.input)
+
+            if (err != nil) != tt.hasError {
+                t.Errorf("ValidateVerbose() error = %v, wantErr %v",
+                    err, tt.hasError)
+                return
+            }
+
+            if got.Valid != tt.expectedValid ||
+                got.FirstBadIndex != tt.expectedBad ||
+                got.ExpectedCheck != tt.expectedCheck {
+                t.Errorf("ValidateVerbose() = %+v", got)
+            }
+
+            if len(got.Checks) != len(names) {
+                t.Fatalf("ValidateVerbose() checks = %+v, want %d entries",
+                    got.Checks, len(names))
+            }
+            for i, check := range got.Checks {
+                if check.Name != names[i] || check.Passed != tt.expectedPass[i] {
+                    t.Errorf("Checks[%d] = %+v, want %s passed=%v",
+                        i, check, names[i], tt.expectedPass[i])
+                }
+                if check.Detail == "" {
+                    t.Errorf("Checks[%d] has no detail", i)
+                }
+            }
+        })
+    }
+
+    t.Run("Empty reports ErrEmptyInput", func(t *testing.T) {
+        if _, err := ValidateVerbose(""); !errors.Is(err, ErrEmptyInput) {
+            t.Errorf("ValidateVerbose() error = %v, want ErrEmptyInput", err)
+        }
+    })
+}
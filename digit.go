@@ -0,0 +1,68 @@
+// FilePath: digit.go
+
+package verhoeff
+
+import (
+    "fmt"
+)
+
+// Digit is a single decimal digit, 0 to 9. Values built with NewDigit or
+// DigitsFromInts are range checked once, so GenerateDigits and
+// ValidateDigits need no per-call validation and cannot fail. Converting
+// an out-of-range integer directly, as in Digit(12), bypasses that check and
+// makes those functions panic.
+type Digit uint8
+
+// NewDigit returns v as a Digit, or an error if v is outside 0-9.
+func NewDigit(v int) (Digit, error) {
+    if v < 0 || v > 9 {
+        return 0, fmt.Errorf("invalid digit: %d", v)
+    }
+    return Digit(v), nil
+}
+
+// DigitsFromInts converts a slice of integers to Digits, returning an error
+// naming the first element outside 0-9.
+func DigitsFromInts(digits []int) ([]Digit, error) {
+    result := make([]Digit, len(digits))
+    for i, v := range digits {
+        digit, err := NewDigit(v)
+        if err != nil {
+            return nil, fmt.Errorf("index %d: %w", i, err)
+        }
+        result[i] = digit
+    }
+    return result, nil
+}
+
+// DigitsToInts converts Digits back to a slice of integers.
+func DigitsToInts(digits []Digit) []int {
+    result := make([]int, len(digits))
+    for i, digit := range digits {
+        result[i] = int(digit)
+    }
+    return result
+}
+
+// GenerateDigits calculates the Verhoeff checksum digit for a slice of
+// Digits. It does not allocate.
+func GenerateDigits(digits []Digit) int {
+    c := 0
+    for i := len(digits) - 1; i >= 0; i-- {
+        c = d[c][p[(len(digits)-i)%8][digits[i]]]
+    }
+    return inv[c]
+}
+
+// ValidateDigits checks if a slice of Digits ending in its checksum digit is
+// valid. An empty slice is not valid.
+func ValidateDigits(digits []Digit) bool {
+    if len(digits) == 0 {
+        return false
+    }
+    c := 0
+    for i := len(digits) - 1; i >= 0; i-- {
+        c = d[c][p[(len(digits)-1-i)%8][digits[i]]]
+    }
+    return c == 0
+}
@@ -0,0 +1,87 @@
+// FilePath: digit_test.go
+
+package verhoeff
+
+import (
+    "testing"
+)
+
+func TestNewDigit(t *testing.T) {
+    tests := []struct {
+        name     string
+        input    int
+        expected Digit
+        hasError bool
+    }{
+        {"Zero", 0, 0, false},
+        {"Nine", 9, 9, false},
+        {"Too large", 10, 0, true},
+        {"Negative", -1, 0, true},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            got, err := NewDigit(tt.input)
+
+            if (err != nil) != tt.hasError {
+                t.Errorf("NewDigit() error = %v, wantErr %v", err, tt.hasError)
+                return
+            }
+
+            if got != tt.expected {
+                t.Errorf("NewDigit() = %v, want %v", got, tt.expected)
+            }
+        })
+    }
+}
+
+func TestDigits(t *testing.T) {
+    tests := []struct {
+        name          string
+        input         []int
+        expectedDigit int
+        expectedValid bool
+        hasError      bool
+    }{
+        {"Valid number", []int{1, 2, 3, 4, 5, 1}, 0, true, false},
+        {"Payload only", []int{1, 2, 3, 4, 5}, 1, false, false},
+        {"Longer than 8 digits", []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 0, 1, 2},
+            0, false, false},
+        {"Empty slice", []int{}, 0, false, false},
+        {"Invalid digit", []int{1, 2, 10}, 0, false, true},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            digits, err := DigitsFromInts(tt.input)
+
+            if (err != nil) != tt.hasError {
+                t.Errorf("DigitsFromInts() error = %v, wantErr %v",
+                    err, tt.hasError)
+                return
+            }
+
+            if tt.hasError {
+                return
+            }
+
+            if got := GenerateDigits(digits); got != tt.expectedDigit {
+                t.Errorf("GenerateDigits() = %v, want %v",
+                    got, tt.expectedDigit)
+            }
+
+            if got := ValidateDigits(digits); got != tt.expectedValid {
+                t.Errorf("ValidateDigits() = %v, want %v",
+                    got, tt.expectedValid)
+            }
+
+            back := DigitsToInts(digits)
+            for i := range back {
+                if back[i] != tt.input[i] {
+                    t.Errorf("DigitsToInts() = %v, want %v", back, tt.input)
+                    break
+                }
+            }
+        })
+    }
+}
@@ -0,0 +1,243 @@
+// FilePath: format.go
+
+package verhoeff
+
+import (
+    "errors"
+    "fmt"
+    "strconv"
+    "strings"
+)
+
+// lenientSeparators are the separators removed by the lenient functions.
+var lenientSeparators = []rune{' ', '-'}
+
+// stripSeparators removes every rune in removable from s.
+func stripSeparators(s string, removable []rune) string {
+    return strings.Map(func(r rune) rune {
+        for _, sep := range removable {
+            if r == sep {
+                return -1
+            }
+        }
+        return r
+    }, s)
+}
+
+// GenerateLenient calculates the Verhoeff checksum digit for a string of
+// digits after removing ASCII spaces and hyphens.
+func GenerateLenient(s string) (int, error) {
+    return GenerateFromString(stripSeparators(s, lenientSeparators))
+}
+
+// ValidateLenient checks if a number with its checksum digit is valid after
+// removing ASCII spaces and hyphens.
+func ValidateLenient(s string) (bool, error) {
+    return ValidateString(stripSeparators(s, lenientSeparators))
+}
+
+// GenerateLenientUnicode works like GenerateLenient but additionally removes
+// the runes in removable, such as U+2009 (thin space) or U+00A0 (no-break
+// space), which some feeds use to group digits.
+func GenerateLenientUnicode(s string, removable []rune) (int, error) {
+    stripped := stripSeparators(s, lenientSeparators)
+    return GenerateFromString(stripSeparators(stripped, removable))
+}
+
+// AppendChecksumAlpha adds the checksum digit to s encoded as a letter,
+// 'A' for 0 through 'J' for 9, so it stands out from the payload.
+func AppendChecksumAlpha(s string) (string, error) {
+    checksum, err := GenerateFromString(s)
+    if err != nil {
+        return "", err
+    }
+    return s + string(rune('A'+checksum)), nil
+}
+
+// ValidateAlpha checks a number whose trailing checksum digit is encoded as
+// a letter from 'A' to 'J', as produced by AppendChecksumAlpha.
+func ValidateAlpha(s string) (bool, error) {
+    if s == "" {
+        return false, ErrEmptyInput
+    }
+
+    last := s[len(s)-1]
+    if last < 'A' || last > 'J' {
+        return false, errors.New("check character must be a letter from A to J")
+    }
+    return ValidateString(s[:len(s)-1] + string(rune('0'+last-'A')))
+}
+
+// Canonicalize validates s leniently and returns the bare digit string with
+// ASCII spaces and hyphens removed, so differently formatted inputs for the
+// same number are stored identically. It returns an error if the stripped
+// number fails validation.
+func Canonicalize(s string) (string, error) {
+    stripped := stripSeparators(s, lenientSeparators)
+    valid, err := ValidateString(stripped)
+    if err != nil {
+        return "", err
+    }
+    if !valid {
+        return "", ErrChecksumMismatch
+    }
+    return stripped, nil
+}
+
+// trailingNoise lists the characters ValidateTrimmedTrailing removes from the
+// end of its input.
+const trailingNoise = " \t\r\n"
+
+// ValidateTrimmedTrailing validates s after removing trailing spaces, tabs,
+// carriage returns and newlines, as left behind by file reads and scanners.
+// Only those characters are trimmed, and only at the end; any other
+// non-digit character, or whitespace before the digits, is still an error.
+func ValidateTrimmedTrailing(s string) (bool, error) {
+    return ValidateString(strings.TrimRight(s, trailingNoise))
+}
+
+// GenerateDisplayCode appends the checksum digit to n and splits the result
+// into groups of groupSize digits joined by sep, counting from the left, so
+// 123456789012 with groups of 4 and a space becomes "1234 5678 9012 0". The
+// separator may be any string, including non-ASCII ones such as a thin
+// space; the digits themselves are always ASCII. n must not be negative.
+func GenerateDisplayCode(n int64, groupSize int, sep string) (string, error) {
+    if n < 0 {
+        return "", errors.New("display codes cannot be negative")
+    }
+    if groupSize <= 0 {
+        return "", errors.New("group size must be positive")
+    }
+
+    return groupDigits(AppendChecksumInt64(n), groupSize, sep), nil
+}
+
+// groupDigits splits an ASCII digit string into groups of groupSize,
+// counting from the left, joined by sep. groupSize must be positive.
+func groupDigits(code string, groupSize int, sep string) string {
+    var b strings.Builder
+    b.Grow(len(code) + len(code)/groupSize*len(sep))
+    for i := 0; i < len(code); i += groupSize {
+        if i > 0 {
+            b.WriteString(sep)
+        }
+        end := i + groupSize
+        if end > len(code) {
+            end = len(code)
+        }
+        b.WriteString(code[i:end])
+    }
+    return b.String()
+}
+
+// ParseDisplayCode reverses GenerateDisplayCode: it removes every occurrence
+// of sep from code, validates the checksum digit and returns the number
+// without it. Group boundaries are not checked, so codes regrouped or typed
+// without separators are accepted. It returns ErrChecksumMismatch if the
+// check digit is wrong.
+func ParseDisplayCode(code string, sep string) (int64, error) {
+    digits := code
+    if sep != "" {
+        digits = strings.ReplaceAll(code, sep, "")
+    }
+
+    valid, err := ValidateString(digits)
+    if err != nil {
+        return 0, err
+    }
+    if !valid {
+        return 0, ErrChecksumMismatch
+    }
+
+    n, err := strconv.ParseInt(digits[:len(digits)-1], 10, 64)
+    if err != nil {
+        return 0, fmt.Errorf("invalid display code %q: %w", code, err)
+    }
+    return n, nil
+}
+
+// NormalizeAndValidate maps fullwidth digits to ASCII before validating, so
+// numbers typed on CJK keyboards are accepted. The mapping is the one NFKC
+// normalization applies to the fullwidth digit block, U+FF10 ('０') through
+// U+FF19 ('９'), and nothing else: other characters are passed through
+// unchanged and validated as usual. The strict ASCII path is ValidateString.
+func NormalizeAndValidate(s string) (bool, error) {
+    return ValidateString(normalizeFullwidthDigits(s))
+}
+
+// normalizeFullwidthDigits replaces fullwidth digits with their ASCII
+// equivalents.
+func normalizeFullwidthDigits(s string) string {
+    return strings.Map(func(r rune) rune {
+        if r >= '０' && r <= '９' {
+            return '0' + (r - '０')
+        }
+        return r
+    }, s)
+}
+
+// cardPayloadLength is the number of payload digits in a card-style number;
+// the check digit makes it up to four groups of four.
+const cardPayloadLength = 15
+
+// GenerateCardFormat takes a 15-digit payload, with or without spaces or
+// hyphens, appends its checksum digit and returns the result in the
+// card-style layout "#### #### #### ###C", where C is the check digit.
+func GenerateCardFormat(s string) (string, error) {
+    payload := stripSeparators(s, lenientSeparators)
+    if len(payload) != cardPayloadLength {
+        return "", fmt.Errorf("card payload must be %d digits, got %d",
+            cardPayloadLength, len(payload))
+    }
+
+    code, err := AppendChecksumString(payload)
+    if err != nil {
+        return "", err
+    }
+    return groupDigits(code, 4, " "), nil
+}
+
+// ValidateCardFormat checks a 16-digit card-style number produced by
+// GenerateCardFormat. Spaces and hyphens are ignored, so the grouping does
+// not have to match.
+func ValidateCardFormat(s string) (bool, error) {
+    code := stripSeparators(s, lenientSeparators)
+    if len(code) != cardPayloadLength+1 {
+        return false, fmt.Errorf("card numbers must be %d digits, got %d",
+            cardPayloadLength+1, len(code))
+    }
+    return ValidateString(code)
+}
+
+// autoSeparators are the separator styles ValidateAuto recognises.
+const autoSeparators = " -."
+
+// ValidateAuto detects the separator style of s, strips it and validates the
+// remaining digits, returning them as normalized. A style is one of space,
+// hyphen or dot, used any number of times. Input mixing two or more styles,
+// such as "1234-5678.9", is treated as ambiguous and rejected with an
+// error, as is any other non-digit character. normalized is returned
+// whenever there is no error, valid or not.
+func ValidateAuto(s string) (valid bool, normalized string, err error) {
+    var style rune
+    for _, r := range s {
+        if !strings.ContainsRune(autoSeparators, r) {
+            continue
+        }
+        if style != 0 && r != style {
+            return false, "", fmt.Errorf("ambiguous separators %q and %q", style, r)
+        }
+        style = r
+    }
+
+    normalized = s
+    if style != 0 {
+        normalized = strings.ReplaceAll(s, string(style), "")
+    }
+
+    valid, err = ValidateString(normalized)
+    if err != nil {
+        return false, "", err
+    }
+    return valid, normalized, nil
+}
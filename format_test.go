@@ -0,0 +1,449 @@
+// FilePath: format_test.go
+
+package verhoeff
+
+import (
+    "errors"
+    "testing"
+)
+
+func TestGenerateLenient(t *testing.T) {
+    tests := []struct {
+        name          string
+        input         string
+        expectedDigit int
+        hasError      bool
+    }{
+        {"Plain digits", "12345", 1, false},
+        {"Spaces", "12 345", 1, false},
+        {"Hyphens", "1-2-3-4-5", 1, false},
+        {"Mixed separators", "12-3 45", 1, false},
+        {"Other punctuation", "12.345", -1, true},
+        {"Letters", "12a45", -1, true},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            got, err := GenerateLenient(tt.input)
+
+            if (err != nil) != tt.hasError {
+                t.Errorf("GenerateLenient() error = %v, wantErr %v",
+                    err, tt.hasError)
+                return
+            }
+
+            if got != tt.expectedDigit {
+                t.Errorf("GenerateLenient() = %v, want %v",
+                    got, tt.expectedDigit)
+            }
+        })
+    }
+}
+
+func TestValidateLenient(t *testing.T) {
+    tests := []struct {
+        name     string
+        input    string
+        expected bool
+        hasError bool
+    }{
+        {"Grouped valid", "1234 51", true, false},
+        {"Hyphenated valid", "123-451", true, false},
+        {"Grouped invalid", "1234 50", false, false},
+        {"Only separators", " - ", false, true},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            got, err := ValidateLenient(tt.input)
+
+            if (err != nil) != tt.hasError {
+                t.Errorf("ValidateLenient() error = %v, wantErr %v",
+                    err, tt.hasError)
+                return
+            }
+
+            if got != tt.expected {
+                t.Errorf("ValidateLenient() = %v, want %v", got, tt.expected)
+            }
+        })
+    }
+}
+
+func TestGenerateLenientUnicode(t *testing.T) {
+    tests := []struct {
+        name          string
+        input         string
+        removable     []rune
+        expectedDigit int
+        hasError      bool
+    }{
+        {"Thin space", "12\u2009345", []rune{'\u2009'}, 1, false},
+        {"No-break space", "12\u00a0345", []rune{'\u00a0', '\u2009'}, 1, false},
+        {"ASCII separators still removed", "12 3-45", nil, 1, false},
+        {"Separator not listed", "12\u2009345", []rune{'\u00a0'}, -1, true},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            got, err := GenerateLenientUnicode(tt.input, tt.removable)
+
+            if (err != nil) != tt.hasError {
+                t.Errorf("GenerateLenientUnicode() error = %v, wantErr %v",
+                    err, tt.hasError)
+                return
+            }
+
+            if got != tt.expectedDigit {
+                t.Errorf("GenerateLenientUnicode() = %v, want %v",
+                    got, tt.expectedDigit)
+            }
+        })
+    }
+}
+
+func TestAppendChecksumAlpha(t *testing.T) {
+    tests := []struct {
+        name     string
+        input    string
+        expected string
+        hasError bool
+    }{
+        {"Check digit 3", "236", "236D", false},
+        {"Check digit 1", "12345", "12345B", false},
+        {"Check digit 0", "142857", "142857A", false},
+        {"Non-digit input", "12a", "", true},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            got, err := AppendChecksumAlpha(tt.input)
+
+            if (err != nil) != tt.hasError {
+                t.Errorf("AppendChecksumAlpha() error = %v, wantErr %v",
+                    err, tt.hasError)
+                return
+            }
+
+            if got != tt.expected {
+                t.Errorf("AppendChecksumAlpha() = %v, want %v",
+                    got, tt.expected)
+            }
+        })
+    }
+}
+
+func TestValidateAlpha(t *testing.T) {
+    tests := []struct {
+        name     string
+        input    string
+        expected bool
+        hasError bool
+    }{
+        {"Valid 236D", "236D", true, false},
+        {"Wrong letter", "236E", false, false},
+        {"Valid 142857A", "142857A", true, false},
+        {"Letter only", "E", false, false},
+        {"Trailing digit", "2363", false, true},
+        {"Letter out of range", "236K", false, true},
+        {"Lowercase letter", "236d", false, true},
+        {"Non-digit payload", "2a6D", false, true},
+        {"Empty input", "", false, true},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            got, err := ValidateAlpha(tt.input)
+
+            if (err != nil) != tt.hasError {
+                t.Errorf("ValidateAlpha() error = %v, wantErr %v",
+                    err, tt.hasError)
+                return
+            }
+
+            if got != tt.expected {
+                t.Errorf("ValidateAlpha() = %v, want %v", got, tt.expected)
+            }
+        })
+    }
+}
+
+func TestCanonicalize(t *testing.T) {
+    tests := []struct {
+        name     string
+        input    string
+        expected string
+        hasError bool
+    }{
+        {"Bare digits", "123451", "123451", false},
+        {"Spaces", "123 451", "123451", false},
+        {"Hyphens", "1234-5-1", "123451", false},
+        {"Invalid checksum", "123 450", "", true},
+        {"Letters", "123a51", "", true},
+        {"Only separators", "- -", "", true},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            got, err := Canonicalize(tt.input)
+
+            if (err != nil) != tt.hasError {
+                t.Errorf("Canonicalize() error = %v, wantErr %v",
+                    err, tt.hasError)
+                return
+            }
+
+            if got != tt.expected {
+                t.Errorf("Canonicalize() = %v, want %v", got, tt.expected)
+            }
+        })
+    }
+}
+
+func TestValidateTrimmedTrailing(t *testing.T) {
+    tests := []struct {
+        name     string
+        input    string
+        expected bool
+        hasError bool
+    }{
+        {"No trailing noise", "2363", true, false},
+        {"Trailing CRLF", "2363\r\n", true, false},
+        {"Trailing spaces and tab", "2363 \t ", true, false},
+        {"Invalid with newline", "2364\n", false, false},
+        {"Leading space", " 2363", false, true},
+        {"Internal space", "23 63", false, true},
+        {"Trailing letter", "2363x", false, true},
+        {"Only whitespace", "\r\n", false, true},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            got, err := ValidateTrimmedTrailing(tt.input)
+
+            if (err != nil) != tt.hasError {
+                t.Errorf("ValidateTrimmedTrailing() error = %v, wantErr %v",
+                    err, tt.hasError)
+                return
+            }
+
+            if got != tt.expected {
+                t.Errorf("ValidateTrimmedTrailing() = %v, want %v",
+                    got, tt.expected)
+            }
+        })
+    }
+}
+
+func TestGenerateDisplayCode(t *testing.T) {
+    tests := []struct {
+        name      string
+        input     int64
+        groupSize int
+        sep       string
+        expected  string
+        hasError  bool
+    }{
+        {"Groups of four", 123456789012, 4, " ", "1234 5678 9012 0", false},
+        {"Groups of three with hyphen", 12345, 3, "-", "123-451", false},
+        {"Thin space separator", 236, 2, "\u2009", "23\u200963", false},
+        {"Single group", 236, 8, " ", "2363", false},
+        {"Empty separator", 12345, 2, "", "123451", false},
+        {"Zero", 0, 4, " ", "04", false},
+        {"Negative number", -236, 4, " ", "", true},
+        {"Zero group size", 236, 0, " ", "", true},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            got, err := GenerateDisplayCode(tt.input, tt.groupSize, tt.sep)
+
+            if (err != nil) != tt.hasError {
+                t.Errorf("GenerateDisplayCode() error = %v, wantErr %v",
+                    err, tt.hasError)
+                return
+            }
+
+            if got != tt.expected {
+                t.Errorf("GenerateDisplayCode() = %q, want %q", got, tt.expected)
+            }
+        })
+    }
+}
+
+func TestParseDisplayCode(t *testing.T) {
+    tests := []struct {
+        name     string
+        input    string
+        sep      string
+        expected int64
+        hasError bool
+    }{
+        {"Groups of four", "1234 5678 9012 0", " ", 123456789012, false},
+        {"Thin space separator", "23\u200963", "\u2009", 236, false},
+        {"Typed without separators", "123451", " ", 12345, false},
+        {"Regrouped", "12 345 1", " ", 12345, false},
+        {"Max int64", "9223372036854775807 4", " ", 9223372036854775807, false},
+        {"Wrong check digit", "1234 5678 9012 1", " ", 0, true},
+        {"Other separator left in", "123-451", " ", 0, true},
+        {"Only separators", "  ", " ", 0, true},
+        {"Overflows int64", "99999999999999999999 5", " ", 0, true},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            got, err := ParseDisplayCode(tt.input, tt.sep)
+
+            if (err != nil) != tt.hasError {
+                t.Errorf("ParseDisplayCode() error = %v, wantErr %v",
+                    err, tt.hasError)
+                return
+            }
+
+            if got != tt.expected {
+                t.Errorf("ParseDisplayCode() = %v, want %v", got, tt.expected)
+            }
+        })
+    }
+
+    t.Run("Wrong check digit reports ErrChecksumMismatch", func(t *testing.T) {
+        _, err := ParseDisplayCode("2364", " ")
+        if !errors.Is(err, ErrChecksumMismatch) {
+            t.Errorf("ParseDisplayCode() error = %v, want ErrChecksumMismatch", err)
+        }
+    })
+}
+
+func TestNormalizeAndValidate(t *testing.T) {
+    tests := []struct {
+        name     string
+        input    string
+        expected bool
+        hasError bool
+    }{
+        {"ASCII digits", "123451", true, false},
+        {"Fullwidth digits", "１２３４５１", true, false},
+        {"Mixed widths", "123４５１", true, false},
+        {"Fullwidth invalid", "２３６４", false, false},
+        {"Fullwidth letter", "123Ａ", false, true},
+        {"Empty string", "", false, true},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            got, err := NormalizeAndValidate(tt.input)
+
+            if (err != nil) != tt.hasError {
+                t.Errorf("NormalizeAndValidate() error = %v, wantErr %v",
+                    err, tt.hasError)
+                return
+            }
+
+            if got != tt.expected {
+                t.Errorf("NormalizeAndValidate() = %v, want %v",
+                    got, tt.expected)
+            }
+        })
+    }
+}
+
+func TestGenerateCardFormat(t *testing.T) {
+    tests := []struct {
+        name     string
+        input    string
+        expected string
+        hasError bool
+    }{
+        {"Bare payload", "123456789012345", "1234 5678 9012 3455", false},
+        {"Grouped payload", "1234 5678 9012 345", "1234 5678 9012 3455", false},
+        {"Hyphenated payload", "1234-5678-9012-345", "1234 5678 9012 3455", false},
+        {"Leading zeros kept", "000000000000000", "0000 0000 0000 0002", false},
+        {"Too short", "12345678901234", "", true},
+        {"Too long", "1234567890123456", "", true},
+        {"Letter", "12345678901234a", "", true},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            got, err := GenerateCardFormat(tt.input)
+
+            if (err != nil) != tt.hasError {
+                t.Errorf("GenerateCardFormat() error = %v, wantErr %v",
+                    err, tt.hasError)
+                return
+            }
+
+            if got != tt.expected {
+                t.Errorf("GenerateCardFormat() = %q, want %q", got, tt.expected)
+            }
+        })
+    }
+}
+
+func TestValidateCardFormat(t *testing.T) {
+    tests := []struct {
+        name     string
+        input    string
+        expected bool
+        hasError bool
+    }{
+        {"Grouped", "1234 5678 9012 3455", true, false},
+        {"Bare", "1234567890123455", true, false},
+        {"Wrong check digit", "1234 5678 9012 3456", false, false},
+        {"Payload only", "1234 5678 9012 345", false, true},
+        {"Letter", "1234 5678 9012 345a", false, true},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            got, err := ValidateCardFormat(tt.input)
+
+            if (err != nil) != tt.hasError {
+                t.Errorf("ValidateCardFormat() error = %v, wantErr %v",
+                    err, tt.hasError)
+                return
+            }
+
+            if got != tt.expected {
+                t.Errorf("ValidateCardFormat() = %v, want %v", got, tt.expected)
+            }
+        })
+    }
+}
+
+func TestValidateAuto(t *testing.T) {
+    tests := []struct {
+        name               string
+        input              string
+        expected           bool
+        expectedNormalized string
+        hasError           bool
+    }{
+        {"No separators", "123451", true, "123451", false},
+        {"Spaces", "123 451", true, "123451", false},
+        {"Hyphens", "12-34-51", true, "123451", false},
+        {"Dots", "1.2345.1", true, "123451", false},
+        {"Invalid checksum", "123-450", false, "123450", false},
+        {"Hyphens and dots", "12-34.51", false, "", true},
+        {"Spaces and hyphens", "12 34-51", false, "", true},
+        {"Other separator", "12/3451", false, "", true},
+        {"Only separators", "--", false, "", true},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            got, normalized, err := ValidateAuto(tt.input)
+
+            if (err != nil) != tt.hasError {
+                t.Errorf("ValidateAuto() error = %v, wantErr %v",
+                    err, tt.hasError)
+                return
+            }
+
+            if got != tt.expected || normalized != tt.expectedNormalized {
+                t.Errorf("ValidateAuto() = (%v, %q), want (%v, %q)",
+                    got, normalized, tt.expected, tt.expectedNormalized)
+            }
+        })
+    }
+}
@@ -0,0 +1,45 @@
+// FilePath: generic.go
+
+package verhoeff
+
+import (
+    "strconv"
+)
+
+// Integer is the set of integer types accepted by the generic functions. It
+// matches golang.org/x/exp/constraints.Integer without adding a dependency.
+type Integer interface {
+    ~int | ~int8 | ~int16 | ~int32 | ~int64 |
+        ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+// magnitude returns the absolute value of n widened to uint64, so the full
+// range of every Integer type, including uint64, is handled.
+func magnitude[T Integer](n T) uint64 {
+    if n < 0 {
+        return absInt64(int64(n))
+    }
+    return uint64(n)
+}
+
+// GenerateNumber calculates the Verhoeff checksum digit for any integer type.
+// Negative numbers are treated by absolute value, like GenerateInt.
+func GenerateNumber[T Integer](n T) int {
+    return inv[uint64Recurrence(magnitude(n), 1)]
+}
+
+// ValidateNumber checks if an integer of any type with its checksum digit is
+// valid.
+func ValidateNumber[T Integer](n T) bool {
+    return uint64Recurrence(magnitude(n), 0) == 0
+}
+
+// AppendChecksumNumber adds the calculated checksum digit to an integer of
+// any type, formatting the result like AppendChecksumInt.
+func AppendChecksumNumber[T Integer](n T) string {
+    digits := strconv.FormatUint(magnitude(n), 10)
+    if n < 0 {
+        digits = "-" + digits
+    }
+    return digits + strconv.Itoa(GenerateNumber(n))
+}
@@ -0,0 +1,87 @@
+// FilePath: generic_test.go
+
+package verhoeff
+
+import (
+    "math"
+    "testing"
+)
+
+type customID uint32
+
+func TestGenerateNumber(t *testing.T) {
+    tests := []struct {
+        name     string
+        got      int
+        expected int
+    }{
+        {"int", GenerateNumber(12345), 1},
+        {"int8", GenerateNumber(int8(127)), 9},
+        {"int8 negative", GenerateNumber(int8(-128)), 0},
+        {"int16", GenerateNumber(int16(236)), 3},
+        {"int32", GenerateNumber(int32(12345)), 1},
+        {"int64 minimum", GenerateNumber(int64(math.MinInt64)), 8},
+        {"uint", GenerateNumber(uint(12345)), 1},
+        {"uint8", GenerateNumber(uint8(236)), 3},
+        {"uint64 maximum", GenerateNumber(uint64(math.MaxUint64)), 3},
+        {"uint64 above int64", GenerateNumber(uint64(1) << 63), 8},
+        {"Defined type", GenerateNumber(customID(12345)), 1},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            if tt.got != tt.expected {
+                t.Errorf("GenerateNumber() = %v, want %v", tt.got, tt.expected)
+            }
+        })
+    }
+}
+
+func TestValidateNumber(t *testing.T) {
+    tests := []struct {
+        name     string
+        got      bool
+        expected bool
+    }{
+        {"int valid", ValidateNumber(2363), true},
+        {"int invalid", ValidateNumber(2364), false},
+        {"int16 valid", ValidateNumber(int16(2363)), true},
+        {"uint32 valid", ValidateNumber(uint32(123451)), true},
+        {"uint64 invalid", ValidateNumber(uint64(123450)), false},
+        {"Defined type", ValidateNumber(customID(2363)), true},
+        {"Negative int64", ValidateNumber(int64(-2363)), true},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            if tt.got != tt.expected {
+                t.Errorf("ValidateNumber() = %v, want %v", tt.got, tt.expected)
+            }
+        })
+    }
+}
+
+func TestAppendChecksumNumber(t *testing.T) {
+    tests := []struct {
+        name     string
+        got      string
+        expected string
+    }{
+        {"int", AppendChecksumNumber(12345), "123451"},
+        {"int matches AppendChecksumInt", AppendChecksumNumber(-236),
+            AppendChecksumInt(-236)},
+        {"uint8", AppendChecksumNumber(uint8(236)), "2363"},
+        {"uint64 maximum", AppendChecksumNumber(uint64(math.MaxUint64)),
+            "184467440737095516153"},
+        {"int8 minimum", AppendChecksumNumber(int8(-128)), "-1280"},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            if tt.got != tt.expected {
+                t.Errorf("AppendChecksumNumber() = %v, want %v",
+                    tt.got, tt.expected)
+            }
+        })
+    }
+}
@@ -0,0 +1,109 @@
+// FilePath: http.go
+
+package verhoeff
+
+import (
+    "encoding/json"
+    "errors"
+    "io"
+    "net/http"
+    "strconv"
+    "strings"
+)
+
+// maxRequestBody caps the size of a request body Handler will read.
+const maxRequestBody = 1 << 20
+
+// validateResponse is the JSON body returned by POST /validate.
+type validateResponse struct {
+    Valid    bool `json:"valid"`
+    Expected int  `json:"expected"`
+}
+
+// generateResponse is the JSON body returned by POST /generate.
+type generateResponse struct {
+    Checksum int    `json:"checksum"`
+    Full     string `json:"full"`
+}
+
+// errorResponse is the JSON body returned for a rejected request.
+type errorResponse struct {
+    Error string `json:"error"`
+}
+
+// Handler returns an http.Handler serving the library as a small JSON API,
+// for running validation as a service without writing the HTTP glue:
+//
+//	POST /validate  body: 2363   -> {"valid":true,"expected":3}
+//	POST /generate  body: 236    -> {"checksum":3,"full":"2363"}
+//
+// The request body is the number as plain text; surrounding whitespace is
+// ignored, and /generate rejects an empty number rather than returning "0".
+// expected is the check digit the payload calls for, whether or not the
+// number is valid. Malformed numbers get a 400 response with a body of the
+// form {"error":"..."}, other methods a 405, and bodies over 1 MiB a 413.
+// To serve it under a prefix, wrap it in http.StripPrefix.
+func Handler() http.Handler {
+    mux := http.NewServeMux()
+    mux.HandleFunc("/validate", func(w http.ResponseWriter, r *http.Request) {
+        s, ok := readNumber(w, r)
+        if !ok {
+            return
+        }
+        valid, err := ValidateString(s)
+        if err != nil {
+            writeJSON(w, http.StatusBadRequest, errorResponse{Error: err.Error()})
+            return
+        }
+        // Slicing s would split a multi-byte final digit.
+        digits, _ := stringToDigits(s)
+        expected := calculateChecksum(digits[:len(digits)-1])
+        writeJSON(w, http.StatusOK, validateResponse{Valid: valid, Expected: expected})
+    })
+    mux.HandleFunc("/generate", func(w http.ResponseWriter, r *http.Request) {
+        s, ok := readNumber(w, r)
+        if !ok {
+            return
+        }
+        if s == "" {
+            writeJSON(w, http.StatusBadRequest, errorResponse{Error: ErrEmptyInput.Error()})
+            return
+        }
+        checksum, err := GenerateFromString(s)
+        if err != nil {
+            writeJSON(w, http.StatusBadRequest, errorResponse{Error: err.Error()})
+            return
+        }
+        writeJSON(w, http.StatusOK, generateResponse{Checksum: checksum, Full: s + strconv.Itoa(checksum)})
+    })
+    return mux
+}
+
+// readNumber reads the number from a POST body, writing an error response
+// and returning ok=false if the request cannot be served.
+func readNumber(w http.ResponseWriter, r *http.Request) (string, bool) {
+    if r.Method != http.MethodPost {
+        w.Header().Set("Allow", http.MethodPost)
+        writeJSON(w, http.StatusMethodNotAllowed, errorResponse{Error: "method not allowed"})
+        return "", false
+    }
+
+    body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxRequestBody))
+    if err != nil {
+        var tooLarge *http.MaxBytesError
+        if errors.As(err, &tooLarge) {
+            writeJSON(w, http.StatusRequestEntityTooLarge, errorResponse{Error: "request body too large"})
+        } else {
+            writeJSON(w, http.StatusBadRequest, errorResponse{Error: "could not read request body"})
+        }
+        return "", false
+    }
+    return strings.TrimSpace(string(body)), true
+}
+
+// writeJSON writes v as a JSON response with the given status code.
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+    w.Header().Set("Content-Type", "application/json")
+    w.WriteHeader(status)
+    _ = json.NewEncoder(w).Encode(v)
+}
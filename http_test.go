@@ -0,0 +1,63 @@
+// FilePath: http_test.go
+
+package verhoeff
+
+import (
+    "net/http"
+    "net/http/httptest"
+    "strings"
+    "testing"
+)
+
+func TestHandler(t *testing.T) {
+    tests := []struct {
+        name           string
+        method         string
+        path           string
+        body           string
+        expectedStatus int
+        expectedBody   string
+    }{
+        {"Validate valid", http.MethodPost, "/validate", "2363", http.StatusOK,
+            `{"valid":true,"expected":3}`},
+        {"Validate invalid", http.MethodPost, "/validate", "2364\n", http.StatusOK,
+            `{"valid":false,"expected":3}`},
+        {"Validate non-ASCII check digit", http.MethodPost, "/validate", "236३", http.StatusOK,
+            `{"valid":false,"expected":3}`},
+        {"Validate empty", http.MethodPost, "/validate", "", http.StatusBadRequest,
+            `{"error":"empty input"}`},
+        {"Validate non-digit", http.MethodPost, "/validate", "12a4", http.StatusBadRequest,
+            `{"error":"input contains non-digit characters: 'a' at offset 2"}`},
+        {"Generate", http.MethodPost, "/generate", " 236 ", http.StatusOK,
+            `{"checksum":3,"full":"2363"}`},
+        {"Generate empty", http.MethodPost, "/generate", "", http.StatusBadRequest,
+            `{"error":"empty input"}`},
+        {"Wrong method", http.MethodGet, "/validate", "", http.StatusMethodNotAllowed,
+            `{"error":"method not allowed"}`},
+        {"Body too large", http.MethodPost, "/generate", strings.Repeat("1", maxRequestBody+1),
+            http.StatusRequestEntityTooLarge, `{"error":"request body too large"}`},
+        {"Unknown path", http.MethodPost, "/other", "2363", http.StatusNotFound, ""},
+    }
+
+    handler := Handler()
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+            rec := httptest.NewRecorder()
+            handler.ServeHTTP(rec, req)
+
+            if rec.Code != tt.expectedStatus {
+                t.Errorf("Handler() status = %d, want %d", rec.Code, tt.expectedStatus)
+            }
+            if tt.expectedBody == "" {
+                return
+            }
+            if got := strings.TrimSpace(rec.Body.String()); got != tt.expectedBody {
+                t.Errorf("Handler() body = %s, want %s", got, tt.expectedBody)
+            }
+            if got := rec.Header().Get("Content-Type"); got != "application/json" {
+                t.Errorf("Handler() Content-Type = %q, want application/json", got)
+            }
+        })
+    }
+}
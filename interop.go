@@ -0,0 +1,275 @@
+// FilePath: interop.go
+
+package verhoeff
+
+import (
+    "errors"
+    "fmt"
+    "strconv"
+    "strings"
+    "unicode/utf8"
+)
+
+// GenerateMasked calculates a checksum digit for s while skipping every
+// position marked with 'X' in ignoreMask, which must be the same length as
+// s. Skipped positions may hold any placeholder character; the remaining
+// digits are checksummed as if the skipped ones were not there.
+//
+// This diverges from standard Verhoeff and only exists to interoperate with
+// legacy formats that exclude placeholder digits from the checksum.
+func GenerateMasked(s string, ignoreMask string) (int, error) {
+    if len(s) != len(ignoreMask) {
+        return -1, errors.New("input and mask must be the same length")
+    }
+
+    var kept strings.Builder
+    kept.Grow(len(s))
+    for i := 0; i < len(s); i++ {
+        if ignoreMask[i] == 'X' {
+            continue
+        }
+        kept.WriteByte(s[i])
+    }
+    return GenerateFromString(kept.String())
+}
+
+// GenerateSalted calculates the checksum digit over salt followed by s. The
+// salt takes part in the checksum but is not meant to be stored with the
+// number, so only s and the returned digit are kept.
+//
+// This is security through obscurity for legacy interop: the salt is easily
+// recovered from a few valid numbers and offers no cryptographic protection.
+func GenerateSalted(salt, s string) (int, error) {
+    if _, err := stringToDigits(salt); err != nil {
+        return -1, err
+    }
+    return GenerateFromString(salt + s)
+}
+
+// ValidateSalted checks a stored number and its checksum digit that were
+// produced by GenerateSalted with the same salt.
+func ValidateSalted(salt, full string) (bool, error) {
+    if full == "" {
+        return false, ErrEmptyInput
+    }
+    if _, err := stringToDigits(salt); err != nil {
+        return false, err
+    }
+    return ValidateString(salt + full)
+}
+
+// GenerateReversedInput calculates the checksum digit over the digits of s
+// in reverse order, matching vendors that feed numbers to Verhoeff
+// least-significant digit first. This is unrelated to the reversal the
+// algorithm performs internally: GenerateReversedInput("12345") equals
+// GenerateFromString("54321"), not GenerateFromString("12345").
+func GenerateReversedInput(s string) (int, error) {
+    digits, err := stringToDigits(s)
+    if err != nil {
+        return -1, err
+    }
+    reverseDigits(digits)
+    return calculateChecksum(digits), nil
+}
+
+// ValidateReversedInput checks a number whose last digit was produced by
+// GenerateReversedInput over the preceding digits.
+func ValidateReversedInput(s string) (bool, error) {
+    digits, err := stringToDigits(s)
+    if err != nil {
+        return false, err
+    }
+    if len(digits) == 0 {
+        return false, ErrEmptyInput
+    }
+
+    payload := digits[:len(digits)-1]
+    reverseDigits(payload)
+    return calculateChecksum(payload) == digits[len(digits)-1], nil
+}
+
+// MapAlphaNumeric expands an alphanumeric identifier into a pure digit
+// string by replacing each letter with its two-digit positional value, A=10
+// through Z=35, as ISIN and similar schemes do before computing a numeric
+// checksum. Digits pass through unchanged, so "US0378331005" maps to
+// "30280378331005". The result can be fed to the standard functions such as
+// GenerateFromString or ValidateString.
+//
+// Only ASCII digits and upper-case letters are accepted; upper-case the
+// input first if the scheme is case-insensitive.
+func MapAlphaNumeric(s string) (string, error) {
+    var b strings.Builder
+    b.Grow(len(s) * 2)
+    for i := 0; i < len(s); i++ {
+        c := s[i]
+        switch {
+        case c >= '0' && c <= '9':
+            b.WriteByte(c)
+        case c >= 'A' && c <= 'Z':
+            value := int(c-'A') + 10
+            b.WriteByte(byte('0' + value/10))
+            b.WriteByte(byte('0' + value%10))
+        default:
+            r, _ := utf8.DecodeRuneInString(s[i:])
+            return "", fmt.Errorf("identifiers must contain only digits and upper-case letters: %w",
+                &InvalidCharError{Char: r, Offset: i})
+        }
+    }
+    return b.String(), nil
+}
+
+// GenerateInterleaved calculates the checksum digit for a composite key built
+// by interleaving two equal-length numbers digit by digit, starting with a:
+// a[0], b[0], a[1], b[1], ..., a[n-1], b[n-1]. For a = "123" and b = "456"
+// the checksum is computed over "142536".
+func GenerateInterleaved(a, b string) (int, error) {
+    if len(a) != len(b) {
+        return -1, errors.New("interleaved numbers must be the same length")
+    }
+
+    interleaved := make([]byte, 0, len(a)+len(b))
+    for i := 0; i < len(a); i++ {
+        interleaved = append(interleaved, a[i], b[i])
+    }
+    return GenerateBytes(interleaved)
+}
+
+// ValidateInterleaved checks a composite key in the form GenerateInterleaved
+// describes: the interleaved digits of two equal-length numbers followed by
+// the checksum digit, so an odd number of digits in total.
+func ValidateInterleaved(s string) (bool, error) {
+    if s == "" {
+        return false, ErrEmptyInput
+    }
+    if len(s)%2 == 0 {
+        return false, errors.New("interleaved key must have an odd number of digits")
+    }
+    return ValidateString(s)
+}
+
+// remapDigits parses s and replaces each digit x with remap[x], after
+// checking that remap is a permutation of 0-9.
+func remapDigits(s string, remap [10]int) ([]int, error) {
+    var seen [10]bool
+    for _, v := range remap {
+        if v < 0 || v > 9 || seen[v] {
+            return nil, errors.New("remap must be a permutation of 0-9")
+        }
+        seen[v] = true
+    }
+
+    digits, err := stringToDigits(s)
+    if err != nil {
+        return nil, err
+    }
+    for i, digit := range digits {
+        digits[i] = remap[digit]
+    }
+    return digits, nil
+}
+
+// GenerateRemapped calculates the checksum digit over the logical digits of
+// s for scrambled keypads, where remap[x] is the logical digit behind the
+// transmitted digit x. The result is the logical check digit; remap must be
+// a permutation of 0-9.
+func GenerateRemapped(s string, remap [10]int) (int, error) {
+    digits, err := remapDigits(s, remap)
+    if err != nil {
+        return -1, err
+    }
+    return calculateChecksum(digits), nil
+}
+
+// ValidateRemapped checks a number entered entirely on a scrambled keypad,
+// check digit included, by mapping every transmitted digit through remap as
+// GenerateRemapped does and validating the logical number.
+func ValidateRemapped(s string, remap [10]int) (bool, error) {
+    digits, err := remapDigits(s, remap)
+    if err != nil {
+        return false, err
+    }
+    if len(digits) == 0 {
+        return false, ErrEmptyInput
+    }
+    return validateChecksum(digits), nil
+}
+
+// GenerateOffsetFromEnd calculates the check digit over all digits of s, in
+// order, and inserts it so that offset digits of s follow it, for schemes
+// that put fixed trailing digits such as a region code after the check
+// digit. offset 0 appends it as usual; GenerateOffsetFromEnd("1234567", 2)
+// checksums "1234567" and returns "12345" + check + "67".
+func GenerateOffsetFromEnd(s string, offset int) (string, error) {
+    digits, err := stringToDigits(s)
+    if err != nil {
+        return "", err
+    }
+    if offset < 0 || offset > len(digits) {
+        return "", fmt.Errorf("offset %d out of range for %d digits",
+            offset, len(digits))
+    }
+
+    checksum := calculateChecksum(digits)
+    split := len(digits) - offset
+    full := append(digits[:split:split], checksum)
+    full = append(full, digits[split:]...)
+    return digitsToString(full), nil
+}
+
+// ValidateOffsetFromEnd checks a number whose check digit sits offset
+// positions before the end, as produced by GenerateOffsetFromEnd. offset 0
+// is the usual last-digit case and offset 2 means the check digit is third
+// from the end. The check digit is compared with the checksum of all other
+// digits taken in their original left-to-right order, with the trailing
+// digits last; the algorithm's internal reversal then starts from the final
+// trailing digit, not from the check digit.
+func ValidateOffsetFromEnd(s string, offset int) (bool, error) {
+    digits, err := stringToDigits(s)
+    if err != nil {
+        return false, err
+    }
+    if len(digits) == 0 {
+        return false, ErrEmptyInput
+    }
+    if offset < 0 || offset >= len(digits) {
+        return false, fmt.Errorf("offset %d out of range for %d digits",
+            offset, len(digits))
+    }
+
+    pos := len(digits) - 1 - offset
+    check := digits[pos]
+    payload := append(digits[:pos:pos], digits[pos+1:]...)
+    return calculateChecksum(payload) == check, nil
+}
+
+// PrependChecksumString calculates the check digit over s and puts it in
+// front, for legacy systems that lead with the check digit: "236" gives
+// "3236". The digits of s are weighted exactly as in AppendChecksumString,
+// so the check digit value is the same; only its place differs. An empty s
+// gives "0", as AppendChecksumString("") does.
+//
+// Prefixed and suffixed numbers are not interchangeable. Moving the check
+// digit shifts the position of every other digit, so a number built by
+// AppendChecksumString will not pass ValidatePrefixed, nor this one
+// ValidateString, except by coincidence.
+func PrependChecksumString(s string) (string, error) {
+    checksum, err := GenerateFromString(s)
+    if err != nil {
+        return "", err
+    }
+    return strconv.Itoa(checksum) + s, nil
+}
+
+// ValidatePrefixed checks a number produced by PrependChecksumString: the
+// first digit must equal the check digit calculated over the rest. This is
+// ValidateOffsetFromEnd with the offset at its maximum.
+func ValidatePrefixed(s string) (bool, error) {
+    digits, err := stringToDigits(s)
+    if err != nil {
+        return false, err
+    }
+    if len(digits) == 0 {
+        return false, ErrEmptyInput
+    }
+    return calculateChecksum(digits[1:]) == digits[0], nil
+}
@@ -0,0 +1,484 @@
+// FilePath: interop_test.go
+
+package verhoeff
+
+import (
+    "errors"
+    "strings"
+    "testing"
+)
+
+func TestGenerateMasked(t *testing.T) {
+    tests := []struct {
+        name          string
+        input         string
+        mask          string
+        expectedDigit int
+        hasError      bool
+    }{
+        {"Empty mask matches standard", "12345", ".....", 1, false},
+        {"Skip placeholder zero", "120345", "..X...", 1, false},
+        {"Skip placeholder letters", "2AB36", ".XX..", 3, false},
+        {"Skip changes the result", "12345", "..X..", 4, false},
+        {"Length mismatch", "12345", "...", -1, true},
+        {"Non-digit in kept position", "12a45", ".....", -1, true},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            got, err := GenerateMasked(tt.input, tt.mask)
+
+            if (err != nil) != tt.hasError {
+                t.Errorf("GenerateMasked() error = %v, wantErr %v",
+                    err, tt.hasError)
+                return
+            }
+
+            if tt.hasError {
+                return
+            }
+
+            if got != tt.expectedDigit {
+                t.Errorf("GenerateMasked() = %v, want %v",
+                    got, tt.expectedDigit)
+            }
+        })
+    }
+}
+
+func TestSalted(t *testing.T) {
+    tests := []struct {
+        name          string
+        salt          string
+        input         string
+        expectedDigit int
+        hasError      bool
+    }{
+        {"No salt", "", "12345", 1, false},
+        {"Salt 99", "99", "12345", 4, false},
+        {"Salt 00", "00", "236", 7, false},
+        {"Non-digit salt", "9a", "12345", -1, true},
+        {"Non-digit input", "99", "12a45", -1, true},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            got, err := GenerateSalted(tt.salt, tt.input)
+
+            if (err != nil) != tt.hasError {
+                t.Errorf("GenerateSalted() error = %v, wantErr %v",
+                    err, tt.hasError)
+                return
+            }
+
+            if tt.hasError {
+                return
+            }
+
+            if got != tt.expectedDigit {
+                t.Errorf("GenerateSalted() = %v, want %v",
+                    got, tt.expectedDigit)
+            }
+
+            full := tt.input + string(rune('0'+got))
+            valid, err := ValidateSalted(tt.salt, full)
+            if err != nil || !valid {
+                t.Errorf("ValidateSalted(%s, %s) = %v, %v, want true",
+                    tt.salt, full, valid, err)
+            }
+        })
+    }
+
+    t.Run("Wrong salt", func(t *testing.T) {
+        valid, err := ValidateSalted("98", "123454")
+        if err != nil || valid {
+            t.Errorf("ValidateSalted() = %v, %v, want false", valid, err)
+        }
+    })
+
+    t.Run("Empty number", func(t *testing.T) {
+        if _, err := ValidateSalted("99", ""); err == nil {
+            t.Errorf("ValidateSalted() expected error for empty number")
+        }
+    })
+}
+
+func TestReversedInput(t *testing.T) {
+    tests := []struct {
+        name             string
+        input            string
+        expectedDigit    int
+        standardChecksum int
+    }{
+        {"12345", "12345", 7, 1},
+        {"236", "236", 6, 3},
+        {"Single digit", "5", 8, 8},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            got, err := GenerateReversedInput(tt.input)
+            if err != nil {
+                t.Fatalf("GenerateReversedInput() error = %v", err)
+            }
+            if got != tt.expectedDigit {
+                t.Errorf("GenerateReversedInput() = %v, want %v",
+                    got, tt.expectedDigit)
+            }
+
+            standard, _ := GenerateFromString(tt.input)
+            if standard != tt.standardChecksum {
+                t.Errorf("GenerateFromString() = %v, want %v",
+                    standard, tt.standardChecksum)
+            }
+
+            full := tt.input + string(rune('0'+got))
+            valid, err := ValidateReversedInput(full)
+            if err != nil || !valid {
+                t.Errorf("ValidateReversedInput(%s) = %v, %v, want true",
+                    full, valid, err)
+            }
+        })
+    }
+
+    t.Run("Standard check digit is rejected", func(t *testing.T) {
+        valid, err := ValidateReversedInput("123451")
+        if err != nil || valid {
+            t.Errorf("ValidateReversedInput() = %v, %v, want false", valid, err)
+        }
+    })
+
+    t.Run("Errors", func(t *testing.T) {
+        if _, err := GenerateReversedInput("12a"); err == nil {
+            t.Errorf("GenerateReversedInput() expected error")
+        }
+        if _, err := ValidateReversedInput(""); err == nil {
+            t.Errorf("ValidateReversedInput() expected error for empty input")
+        }
+    })
+}
+
+func TestMapAlphaNumeric(t *testing.T) {
+    tests := []struct {
+        name     string
+        input    string
+        expected string
+        hasError bool
+    }{
+        {"ISIN", "US0378331005", "30280378331005", false},
+        {"Letter bounds", "AZ", "1035", false},
+        {"Digits only", "12345", "12345", false},
+        {"Empty string", "", "", false},
+        {"Lower-case letter", "us037", "", true},
+        {"Punctuation", "US-037", "", true},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            got, err := MapAlphaNumeric(tt.input)
+
+            if (err != nil) != tt.hasError {
+                t.Errorf("MapAlphaNumeric() error = %v, wantErr %v",
+                    err, tt.hasError)
+                return
+            }
+
+            if got != tt.expected {
+                t.Errorf("MapAlphaNumeric() = %v, want %v", got, tt.expected)
+            }
+        })
+    }
+
+    t.Run("Invalid character wraps ErrNonDigit", func(t *testing.T) {
+        _, err := MapAlphaNumeric("US-037")
+        var charErr *InvalidCharError
+        if !errors.As(err, &charErr) || charErr.Char != '-' || charErr.Offset != 2 ||
+            !errors.Is(err, ErrNonDigit) {
+            t.Errorf("MapAlphaNumeric() error = %v, want '-' at offset 2", err)
+        }
+    })
+}
+
+func TestInterleaved(t *testing.T) {
+    tests := []struct {
+        name          string
+        a             string
+        b             string
+        expectedDigit int
+        hasError      bool
+    }{
+        {"Three digits each", "123", "456", 7, false},
+        {"Order matters", "456", "123", 8, false},
+        {"Empty inputs", "", "", 0, false},
+        {"Unequal lengths", "123", "45", -1, true},
+        {"Non-digit", "12a", "456", -1, true},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            got, err := GenerateInterleaved(tt.a, tt.b)
+
+            if (err != nil) != tt.hasError {
+                t.Errorf("GenerateInterleaved() error = %v, wantErr %v",
+                    err, tt.hasError)
+                return
+            }
+
+            if tt.hasError {
+                return
+            }
+
+            if got != tt.expectedDigit {
+                t.Errorf("GenerateInterleaved() = %v, want %v",
+                    got, tt.expectedDigit)
+            }
+
+            var key strings.Builder
+            for i := 0; i < len(tt.a); i++ {
+                key.WriteByte(tt.a[i])
+                key.WriteByte(tt.b[i])
+            }
+            key.WriteByte(byte('0' + got))
+
+            valid, err := ValidateInterleaved(key.String())
+            if err != nil || !valid {
+                t.Errorf("ValidateInterleaved(%q) = %v, %v, want true",
+                    key.String(), valid, err)
+            }
+        })
+    }
+}
+
+func TestValidateInterleaved(t *testing.T) {
+    tests := []struct {
+        name     string
+        input    string
+        expected bool
+        hasError bool
+    }{
+        {"Valid key", "1425367", true, false},
+        {"Wrong check digit", "1425368", false, false},
+        {"Check digit only", "0", true, false},
+        {"Even length", "142536", false, true},
+        {"Empty string", "", false, true},
+        {"Non-digit", "14a5367", false, true},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            got, err := ValidateInterleaved(tt.input)
+
+            if (err != nil) != tt.hasError {
+                t.Errorf("ValidateInterleaved() error = %v, wantErr %v",
+                    err, tt.hasError)
+                return
+            }
+
+            if got != tt.expected {
+                t.Errorf("ValidateInterleaved() = %v, want %v", got, tt.expected)
+            }
+        })
+    }
+}
+
+func TestRemapped(t *testing.T) {
+    identity := [10]int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}
+    reversed := [10]int{9, 8, 7, 6, 5, 4, 3, 2, 1, 0}
+
+    tests := []struct {
+        name          string
+        input         string
+        full          string
+        remap         [10]int
+        expectedDigit int
+        hasError      bool
+    }{
+        {"Identity", "12345", "123451", identity, 1, false},
+        // Logical 12345 is typed as 87654, and its check digit 1 as 8.
+        {"Reversed keypad", "87654", "876548", reversed, 1, false},
+        {"Not a permutation", "12345", "123451",
+            [10]int{0, 0, 2, 3, 4, 5, 6, 7, 8, 9}, -1, true},
+        {"Out of range", "12345", "123451",
+            [10]int{10, 1, 2, 3, 4, 5, 6, 7, 8, 9}, -1, true},
+        {"Non-digit", "12a45", "12a451", identity, -1, true},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            got, err := GenerateRemapped(tt.input, tt.remap)
+
+            if (err != nil) != tt.hasError {
+                t.Errorf("GenerateRemapped() error = %v, wantErr %v",
+                    err, tt.hasError)
+                return
+            }
+
+            if got != tt.expectedDigit {
+                t.Errorf("GenerateRemapped() = %v, want %v",
+                    got, tt.expectedDigit)
+            }
+
+            valid, err := ValidateRemapped(tt.full, tt.remap)
+            if (err != nil) != tt.hasError {
+                t.Errorf("ValidateRemapped() error = %v, wantErr %v",
+                    err, tt.hasError)
+                return
+            }
+            if valid != !tt.hasError {
+                t.Errorf("ValidateRemapped() = %v, want %v", valid, !tt.hasError)
+            }
+        })
+    }
+
+    t.Run("Transmitted digits are not the logical ones", func(t *testing.T) {
+        if valid, _ := ValidateRemapped("123451", reversed); valid {
+            t.Errorf("ValidateRemapped() accepted an unmapped number")
+        }
+        if _, err := ValidateRemapped("", identity); err == nil {
+            t.Errorf("ValidateRemapped() expected error for empty input")
+        }
+    })
+}
+
+func TestOffsetFromEnd(t *testing.T) {
+    tests := []struct {
+        name     string
+        input    string
+        offset   int
+        expected string
+        hasError bool
+    }{
+        {"Offset zero appends", "236", 0, "2363", false},
+        {"Offset two", "1234567", 2, "12345967", false},
+        {"Offset covers whole input", "236", 3, "3236", false},
+        {"Offset too large", "236", 4, "", true},
+        {"Negative offset", "236", -1, "", true},
+        {"Non-digit", "23a", 0, "", true},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            got, err := GenerateOffsetFromEnd(tt.input, tt.offset)
+
+            if (err != nil) != tt.hasError {
+                t.Errorf("GenerateOffsetFromEnd() error = %v, wantErr %v",
+                    err, tt.hasError)
+                return
+            }
+
+            if got != tt.expected {
+                t.Errorf("GenerateOffsetFromEnd() = %v, want %v",
+                    got, tt.expected)
+            }
+
+            if tt.hasError {
+                return
+            }
+
+            valid, err := ValidateOffsetFromEnd(got, tt.offset)
+            if err != nil || !valid {
+                t.Errorf("ValidateOffsetFromEnd(%q, %d) = %v, %v, want true",
+                    got, tt.offset, valid, err)
+            }
+        })
+    }
+}
+
+func TestValidateOffsetFromEnd(t *testing.T) {
+    tests := []struct {
+        name     string
+        input    string
+        offset   int
+        expected bool
+        hasError bool
+    }{
+        {"Offset two", "12345967", 2, true, false},
+        {"Wrong check digit", "12345867", 2, false, false},
+        {"Trailing digit changed", "12345968", 2, false, false},
+        {"Same digits at offset zero", "12345967", 0, false, false},
+        {"Offset zero", "2363", 0, true, false},
+        {"Offset out of range", "2363", 4, false, true},
+        {"Empty string", "", 0, false, true},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            got, err := ValidateOffsetFromEnd(tt.input, tt.offset)
+
+            if (err != nil) != tt.hasError {
+                t.Errorf("ValidateOffsetFromEnd() error = %v, wantErr %v",
+                    err, tt.hasError)
+                return
+            }
+
+            if got != tt.expected {
+                t.Errorf("ValidateOffsetFromEnd() = %v, want %v",
+                    got, tt.expected)
+            }
+        })
+    }
+}
+
+func TestPrependChecksumString(t *testing.T) {
+    tests := []struct {
+        name     string
+        input    string
+        expected string
+        hasError bool
+    }{
+        {"Three digits", "236", "3236", false},
+        {"Five digits", "12345", "112345", false},
+        {"Empty string", "", "0", false},
+        {"Non-digit", "12a", "", true},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            got, err := PrependChecksumString(tt.input)
+
+            if (err != nil) != tt.hasError {
+                t.Errorf("PrependChecksumString() error = %v, wantErr %v",
+                    err, tt.hasError)
+                return
+            }
+
+            if got != tt.expected {
+                t.Errorf("PrependChecksumString() = %v, want %v",
+                    got, tt.expected)
+            }
+        })
+    }
+}
+
+func TestValidatePrefixed(t *testing.T) {
+    tests := []struct {
+        name     string
+        input    string
+        expected bool
+        hasError bool
+    }{
+        {"Prefixed check digit", "3236", true, false},
+        {"Wrong check digit", "4236", false, false},
+        {"Suffixed number", "2363", false, false},
+        {"Check digit only", "0", true, false},
+        {"Empty string", "", false, true},
+        {"Non-digit", "3a36", false, true},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            got, err := ValidatePrefixed(tt.input)
+
+            if (err != nil) != tt.hasError {
+                t.Errorf("ValidatePrefixed() error = %v, wantErr %v",
+                    err, tt.hasError)
+                return
+            }
+
+            if got != tt.expected {
+                t.Errorf("ValidatePrefixed() = %v, want %v",
+                    got, tt.expected)
+            }
+        })
+    }
+}
@@ -0,0 +1,44 @@
+// FilePath: json.go
+
+package verhoeff
+
+import (
+    "encoding/json"
+    "errors"
+    "fmt"
+)
+
+// decodeJSONDigits unmarshals a JSON array of integers such as [1,2,3].
+// JSON null is rejected rather than treated as an empty array.
+func decodeJSONDigits(data []byte) ([]int, error) {
+    var digits []int
+    if err := json.Unmarshal(data, &digits); err != nil {
+        return nil, fmt.Errorf("invalid JSON digit array: %w", err)
+    }
+    if digits == nil {
+        return nil, errors.New("invalid JSON digit array: got null")
+    }
+    return digits, nil
+}
+
+// GenerateJSONArray calculates the Verhoeff checksum digit for a JSON array
+// of digits such as [1,2,3,4,5]. It returns an error for malformed JSON or
+// elements outside 0-9.
+func GenerateJSONArray(data []byte) (int, error) {
+    digits, err := decodeJSONDigits(data)
+    if err != nil {
+        return -1, err
+    }
+    return GenerateSlice(digits)
+}
+
+// ValidateJSONArray checks a JSON array of digits whose last element is the
+// checksum digit, such as [1,2,3,4,5,1]. It returns an error for malformed
+// JSON or elements outside 0-9, and ErrEmptyInput for [].
+func ValidateJSONArray(data []byte) (bool, error) {
+    digits, err := decodeJSONDigits(data)
+    if err != nil {
+        return false, err
+    }
+    return ValidateSlice(digits)
+}
@@ -0,0 +1,63 @@
+// FilePath: json_test.go
+
+package verhoeff
+
+import (
+    "testing"
+)
+
+func TestJSONArray(t *testing.T) {
+    tests := []struct {
+        name          string
+        input         string
+        expectedDigit int
+        expectedValid bool
+        hasError      bool
+    }{
+        {"Valid number", "[1,2,3,4,5,1]", 0, true, false},
+        {"Payload only", "[1, 2, 3, 4, 5]", 1, false, false},
+        {"Single element", "[0]", 4, true, false},
+        {"Out of range element", "[1,2,10]", -1, false, true},
+        {"Negative element", "[1,-2,3]", -1, false, true},
+        {"Fractional element", "[1,2.5,3]", -1, false, true},
+        {"String elements", `["1","2"]`, -1, false, true},
+        {"Not an array", `{"id":1}`, -1, false, true},
+        {"Null", "null", -1, false, true},
+        {"Malformed JSON", "[1,2,", -1, false, true},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            got, err := GenerateJSONArray([]byte(tt.input))
+            if (err != nil) != tt.hasError {
+                t.Errorf("GenerateJSONArray() error = %v, wantErr %v",
+                    err, tt.hasError)
+                return
+            }
+            if got != tt.expectedDigit {
+                t.Errorf("GenerateJSONArray() = %v, want %v",
+                    got, tt.expectedDigit)
+            }
+
+            valid, err := ValidateJSONArray([]byte(tt.input))
+            if (err != nil) != tt.hasError {
+                t.Errorf("ValidateJSONArray() error = %v, wantErr %v",
+                    err, tt.hasError)
+                return
+            }
+            if valid != tt.expectedValid {
+                t.Errorf("ValidateJSONArray() = %v, want %v",
+                    valid, tt.expectedValid)
+            }
+        })
+    }
+
+    t.Run("Empty array", func(t *testing.T) {
+        if got, err := GenerateJSONArray([]byte("[]")); err != nil || got != 0 {
+            t.Errorf("GenerateJSONArray([]) = %v, %v, want 0, nil", got, err)
+        }
+        if _, err := ValidateJSONArray([]byte("[]")); err == nil {
+            t.Errorf("ValidateJSONArray([]) expected error")
+        }
+    })
+}
@@ -0,0 +1,40 @@
+// FilePath: log.go
+
+package verhoeff
+
+import (
+    "context"
+    "log/slog"
+)
+
+// LogInvalid validates s and, if it is not valid, logs a Warn record to
+// logger with the attributes length (of s in bytes), expected_check (-1 if
+// it could not be calculated) and reason (the first failed check from
+// ValidateVerbose). The input itself is not logged, since identifiers are
+// often sensitive. A nil logger uses slog.Default. It returns whether s is
+// valid.
+func LogInvalid(logger *slog.Logger, s string) bool {
+    report, err := ValidateVerbose(s)
+    if err == nil && report.Valid {
+        return true
+    }
+
+    if logger == nil {
+        logger = slog.Default()
+    }
+
+    reason := ""
+    for _, check := range report.Checks {
+        if !check.Passed {
+            reason = check.Name + ": " + check.Detail
+            break
+        }
+    }
+
+    logger.LogAttrs(context.Background(), slog.LevelWarn, "invalid check digit",
+        slog.Int("length", len(s)),
+        slog.Int("expected_check", report.ExpectedCheck),
+        slog.String("reason", reason),
+    )
+    return false
+}
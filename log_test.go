@@ -0,0 +1,61 @@
+// FilePath: log_test.go
+
+package verhoeff
+
+import (
+    "bytes"
+    "encoding/json"
+    "log/slog"
+    "strings"
+    "testing"
+)
+
+func TestLogInvalid(t *testing.T) {
+    tests := []struct {
+        name           string
+        input          string
+        expected       bool
+        expectedCheck  float64
+        expectedReason string
+    }{
+        {"Valid number", "123451", true, 0, ""},
+        {"Wrong check digit", "123459", false, 1, "checksum: expected check digit 1, got 9"},
+        {"Letter", "12a451", false, -1, "all-digits: non-digit 'a' at index 2"},
+        {"Empty string", "", false, -1, "non-empty: input is empty"},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            var buf bytes.Buffer
+            logger := slog.New(slog.NewJSONHandler(&buf, nil))
+
+            got := LogInvalid(logger, tt.input)
+            if got != tt.expected {
+                t.Errorf("LogInvalid() = %v, want %v", got, tt.expected)
+            }
+
+            if tt.expected {
+                if buf.Len() != 0 {
+                    t.Errorf("LogInvalid() logged %q for a valid number", buf.String())
+                }
+                return
+            }
+
+            var record map[string]any
+            if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+                t.Fatalf("LogInvalid() wrote %q: %v", buf.String(), err)
+            }
+
+            if record["level"] != "WARN" ||
+                record["length"] != float64(len(tt.input)) ||
+                record["expected_check"] != tt.expectedCheck ||
+                record["reason"] != tt.expectedReason {
+                t.Errorf("LogInvalid() record = %v", record)
+            }
+
+            if tt.input != "" && strings.Contains(buf.String(), tt.input) {
+                t.Errorf("LogInvalid() leaked the input: %s", buf.String())
+            }
+        })
+    }
+}
@@ -0,0 +1,171 @@
+// FilePath: luhn.go
+
+package verhoeff
+
+import (
+    "fmt"
+)
+
+// luhnSum returns the Luhn sum of digits, doubling every second digit
+// counting from the right. With double set, the rightmost digit is doubled,
+// which is the rule for a payload whose check digit is still to be added.
+func luhnSum(digits []int, double bool) int {
+    sum := 0
+    for i := len(digits) - 1; i >= 0; i-- {
+        digit := digits[i]
+        if double {
+            digit *= 2
+            if digit > 9 {
+                digit -= 9
+            }
+        }
+        sum += digit
+        double = !double
+    }
+    return sum
+}
+
+// luhnChecksum calculates the Luhn check digit for a payload.
+func luhnChecksum(digits []int) int {
+    return (10 - luhnSum(digits, true)%10) % 10
+}
+
+// luhnValid reports whether digits end in a correct Luhn check digit.
+func luhnValid(digits []int) bool {
+    return len(digits) > 0 && luhnSum(digits, false)%10 == 0
+}
+
+// ValidateVerhoeffOrLuhn reports which check-digit scheme s validates under,
+// for accepting both while partners migrate from Luhn. Verhoeff takes
+// precedence: scheme is "verhoeff" if s is a valid Verhoeff number, else
+// "luhn" if it passes the Luhn check, else "" with valid false.
+//
+// About one number in ten that carries a correct Verhoeff digit also passes
+// Luhn by coincidence, and such numbers are always reported as "verhoeff",
+// so the scheme tag says which rule accepted the number, not which one
+// produced it.
+func ValidateVerhoeffOrLuhn(s string) (scheme string, valid bool, err error) {
+    digits, err := stringToDigits(s)
+    if err != nil {
+        return "", false, err
+    }
+    if len(digits) == 0 {
+        return "", false, ErrEmptyInput
+    }
+
+    switch {
+    case validateChecksum(digits):
+        return "verhoeff", true, nil
+    case luhnValid(digits):
+        return "luhn", true, nil
+    default:
+        return "", false, nil
+    }
+}
+
+// DetectedScheme identifies the check-digit scheme a number satisfies, as
+// reported by DetectScheme.
+type DetectedScheme int
+
+const (
+    // SchemeUnknown means the number passes neither check or is malformed.
+    SchemeUnknown DetectedScheme = iota
+    // SchemeVerhoeff means the number carries a correct Verhoeff digit.
+    SchemeVerhoeff
+    // SchemeLuhn means the number fails Verhoeff but passes Luhn, so it
+    // could be a Luhn number.
+    SchemeLuhn
+)
+
+// String returns the name of the scheme, matching the tags
+// ValidateVerhoeffOrLuhn returns, with "unknown" for SchemeUnknown.
+func (s DetectedScheme) String() string {
+    switch s {
+    case SchemeUnknown:
+        return "unknown"
+    case SchemeVerhoeff:
+        return "verhoeff"
+    case SchemeLuhn:
+        return "luhn"
+    default:
+        return fmt.Sprintf("DetectedScheme(%d)", int(s))
+    }
+}
+
+// DetectScheme tags s with the check-digit scheme it satisfies, for auditing
+// records during a Luhn to Verhoeff migration. It follows the same
+// precedence as ValidateVerhoeffOrLuhn, so a number passing both checks is
+// SchemeVerhoeff, and malformed input is SchemeUnknown rather than an error.
+func DetectScheme(s string) DetectedScheme {
+    scheme, _, _ := ValidateVerhoeffOrLuhn(s)
+    switch scheme {
+    case "verhoeff":
+        return SchemeVerhoeff
+    case "luhn":
+        return SchemeLuhn
+    default:
+        return SchemeUnknown
+    }
+}
+
+// IsVerhoeffValid reports whether s is a valid Verhoeff number, treating
+// malformed input as not valid.
+func IsVerhoeffValid(s string) bool {
+    valid, err := ValidateString(s)
+    return err == nil && valid
+}
+
+// migrationSampleSize caps how many affected IDs MigrationImpact keeps.
+const migrationSampleSize = 10
+
+// MigrationReport summarises how a Luhn to Verhoeff migration would affect a
+// set of IDs.
+type MigrationReport struct {
+    // Total is the number of IDs examined.
+    Total int
+    // Changed is the number of IDs whose check digit would change.
+    Changed int
+    // Unchanged is the number of IDs whose current check digit is already
+    // the Verhoeff one.
+    Unchanged int
+    // NotLuhn is the number of examined IDs whose trailing digit is not a
+    // correct Luhn check digit either, which may point at IDs issued under
+    // some other rule.
+    NotLuhn int
+    // ErrorCount is the number of IDs that could not be examined.
+    ErrorCount int
+    // Sample holds up to the first 10 IDs whose check digit would change, in
+    // input order.
+    Sample []string
+}
+
+// MigrationImpact is a dry run of moving IDs from Luhn to Verhoeff check
+// digits. Each element of bases is an ID as currently issued, with its
+// trailing digit assumed to be a Luhn check digit, and the digit is compared
+// as-is against the Verhoeff check digit for the rest of the ID. IDs whose
+// trailing digit fails the Luhn check are still compared, but are also
+// counted in NotLuhn. Malformed IDs are counted rather than aborting the run.
+func MigrationImpact(bases []string) MigrationReport {
+    report := MigrationReport{Total: len(bases), Sample: []string{}}
+    for _, base := range bases {
+        digits, err := stringToDigits(base)
+        if err != nil || len(digits) == 0 {
+            report.ErrorCount++
+            continue
+        }
+
+        if !luhnValid(digits) {
+            report.NotLuhn++
+        }
+        payload := digits[:len(digits)-1]
+        if calculateChecksum(payload) == digits[len(digits)-1] {
+            report.Unchanged++
+            continue
+        }
+        report.Changed++
+        if len(report.Sample) < migrationSampleSize {
+            report.Sample = append(report.Sample, base)
+        }
+    }
+    return report
+}
@@ -0,0 +1,147 @@
+// FilePath: luhn_test.go
+
+package verhoeff
+
+import (
+    "reflect"
+    "testing"
+)
+
+func TestLuhn(t *testing.T) {
+    tests := []struct {
+        name          string
+        payload       []int
+        expectedDigit int
+    }{
+        {"Textbook example", []int{7, 9, 9, 2, 7, 3, 9, 8, 7, 1}, 3},
+        {"Card number", []int{4, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1}, 1},
+        {"Empty payload", []int{}, 0},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            got := luhnChecksum(tt.payload)
+            if got != tt.expectedDigit {
+                t.Errorf("luhnChecksum() = %v, want %v", got, tt.expectedDigit)
+            }
+
+            full := append(append([]int{}, tt.payload...), got)
+            if !luhnValid(full) {
+                t.Errorf("luhnValid(%v) = false, want true", full)
+            }
+
+            full[len(full)-1] = (got + 1) % 10
+            if luhnValid(full) {
+                t.Errorf("luhnValid(%v) = true, want false", full)
+            }
+        })
+    }
+}
+
+func TestValidateVerhoeffOrLuhn(t *testing.T) {
+    tests := []struct {
+        name           string
+        input          string
+        expectedScheme string
+        expectedValid  bool
+        hasError       bool
+    }{
+        {"Verhoeff only", "123451", "verhoeff", true, false},
+        {"Luhn only", "79927398713", "luhn", true, false},
+        {"Both prefer Verhoeff", "1057", "verhoeff", true, false},
+        {"Neither", "2364", "", false, false},
+        {"Empty string", "", "", false, true},
+        {"Non-digit", "12a451", "", false, true},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            scheme, valid, err := ValidateVerhoeffOrLuhn(tt.input)
+
+            if (err != nil) != tt.hasError {
+                t.Errorf("ValidateVerhoeffOrLuhn() error = %v, wantErr %v",
+                    err, tt.hasError)
+                return
+            }
+
+            if scheme != tt.expectedScheme || valid != tt.expectedValid {
+                t.Errorf("ValidateVerhoeffOrLuhn() = (%q, %v), want (%q, %v)",
+                    scheme, valid, tt.expectedScheme, tt.expectedValid)
+            }
+        })
+    }
+}
+
+func TestDetectScheme(t *testing.T) {
+    tests := []struct {
+        name             string
+        input            string
+        expected         DetectedScheme
+        expectedString   string
+        expectedVerhoeff bool
+    }{
+        {"Verhoeff only", "123451", SchemeVerhoeff, "verhoeff", true},
+        {"Luhn only", "79927398713", SchemeLuhn, "luhn", false},
+        {"Both prefer Verhoeff", "1057", SchemeVerhoeff, "verhoeff", true},
+        {"Neither", "2364", SchemeUnknown, "unknown", false},
+        {"Empty string", "", SchemeUnknown, "unknown", false},
+        {"Non-digit", "12a451", SchemeUnknown, "unknown", false},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            got := DetectScheme(tt.input)
+            if got != tt.expected || got.String() != tt.expectedString {
+                t.Errorf("DetectScheme() = %v, want %v", got, tt.expectedString)
+            }
+
+            if valid := IsVerhoeffValid(tt.input); valid != tt.expectedVerhoeff {
+                t.Errorf("IsVerhoeffValid() = %v, want %v", valid, tt.expectedVerhoeff)
+            }
+        })
+    }
+
+    if got := DetectedScheme(7).String(); got != "DetectedScheme(7)" {
+        t.Errorf("DetectedScheme(7).String() = %q, want %q", got, "DetectedScheme(7)")
+    }
+}
+
+func TestMigrationImpact(t *testing.T) {
+    tests := []struct {
+        name     string
+        input    []string
+        expected MigrationReport
+    }{
+        {"Luhn digit kept", []string{"91"},
+            MigrationReport{Total: 1, Unchanged: 1, Sample: []string{}}},
+        {"Luhn digit changed", []string{"79927398713"},
+            MigrationReport{Total: 1, Changed: 1, Sample: []string{"79927398713"}}},
+        {"Not a Luhn ID", []string{"1234", "1233"},
+            MigrationReport{Total: 2, Changed: 1, Unchanged: 1, NotLuhn: 2, Sample: []string{"1234"}}},
+        {"Malformed IDs counted", []string{"", "12a4", "91"},
+            MigrationReport{Total: 3, Unchanged: 1, ErrorCount: 2, Sample: []string{}}},
+        {"Empty input", nil,
+            MigrationReport{Sample: []string{}}},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            got := MigrationImpact(tt.input)
+            if !reflect.DeepEqual(got, tt.expected) {
+                t.Errorf("MigrationImpact() = %+v, want %+v", got, tt.expected)
+            }
+        })
+    }
+
+    t.Run("Sample is capped", func(t *testing.T) {
+        bases := make([]string, migrationSampleSize+5)
+        for i := range bases {
+            bases[i] = "79927398713"
+        }
+        got := MigrationImpact(bases)
+        if got.Changed != len(bases) || len(got.Sample) != migrationSampleSize {
+            t.Errorf("MigrationImpact() = (Changed %d, %d samples), want (%d, %d)",
+                got.Changed, len(got.Sample), len(bases), migrationSampleSize)
+        }
+    })
+}
@@ -0,0 +1,176 @@
+// FilePath: policy.go
+
+package verhoeff
+
+import (
+    "errors"
+    "fmt"
+    "math/rand"
+    "strconv"
+    "strings"
+)
+
+// ErrPrefixMismatch is returned when a number does not start with the
+// required issuer prefix.
+var ErrPrefixMismatch = errors.New("number does not start with the required prefix")
+
+// ErrChecksumMismatch is returned when a number has the right shape but its
+// checksum digit is wrong.
+var ErrChecksumMismatch = errors.New("checksum validation failed")
+
+// maxNonTrivialAttempts bounds how many random bases GenerateNonTrivial draws
+// before giving up.
+const maxNonTrivialAttempts = 1000
+
+// IsTrivial reports whether s is a trivial number that an issuance policy
+// may want to refuse: every digit identical ("111111"), or a run where each
+// digit is one more ("123456") or one less ("987654") than the previous one,
+// wrapping between 9 and 0 so "7890123" also counts.
+//
+// IsTrivial only looks at the digits as given; empty strings and strings with
+// non-digit characters are not considered trivial and should be rejected by
+// validation instead.
+func IsTrivial(s string) bool {
+    if s == "" {
+        return false
+    }
+    for i := 0; i < len(s); i++ {
+        if s[i] < '0' || s[i] > '9' {
+            return false
+        }
+    }
+
+    same, ascending, descending := true, true, true
+    for i := 1; i < len(s); i++ {
+        step := (int(s[i]) - int(s[i-1]) + 10) % 10
+        same = same && step == 0
+        ascending = ascending && step == 1
+        descending = descending && step == 9
+    }
+    return same || ascending || descending
+}
+
+// GenerateNonTrivial draws random bases of the given length from rng until
+// it finds one that IsTrivial rejects, and returns it with its checksum digit
+// appended. Passing a seeded rng makes the output reproducible.
+func GenerateNonTrivial(rng *rand.Rand, length int) (string, error) {
+    if length < 2 {
+        return "", errors.New("length must be at least 2")
+    }
+
+    base := make([]byte, length)
+    for attempt := 0; attempt < maxNonTrivialAttempts; attempt++ {
+        for i := range base {
+            base[i] = byte('0' + rng.Intn(10))
+        }
+        if !IsTrivial(string(base)) {
+            return AppendChecksumString(string(base))
+        }
+    }
+    return "", errors.New("no non-trivial base found")
+}
+
+// ValidateWithPrefix checks that s starts with prefix and carries a valid
+// checksum digit. A wrong prefix returns ErrPrefixMismatch and a wrong
+// checksum digit returns ErrChecksumMismatch, so callers can tell them apart.
+// The prefix is simply the leading digits of s and takes part in the checksum
+// like any other digits.
+func ValidateWithPrefix(s, prefix string) (bool, error) {
+    if _, err := stringToDigits(prefix); err != nil {
+        return false, err
+    }
+    if !strings.HasPrefix(s, prefix) {
+        return false, ErrPrefixMismatch
+    }
+
+    valid, err := ValidateString(s)
+    if err != nil {
+        return false, err
+    }
+    if !valid {
+        return false, ErrChecksumMismatch
+    }
+    return true, nil
+}
+
+// SharesBase reports whether a and b have the same payload, that is, are
+// equal apart from their last (checksum) digit. It is meant for spotting
+// records that hold the same number with different, possibly wrong, check
+// digits. Both inputs must be non-empty digit strings of equal length.
+func SharesBase(a, b string) (bool, error) {
+    if _, err := stringToDigits(a); err != nil {
+        return false, err
+    }
+    if _, err := stringToDigits(b); err != nil {
+        return false, err
+    }
+    if a == "" || b == "" {
+        return false, ErrEmptyInput
+    }
+    if len(a) != len(b) {
+        return false, errors.New("inputs differ in length")
+    }
+    return a[:len(a)-1] == b[:len(b)-1], nil
+}
+
+// NextValidAfter returns the code for the smallest integer m >= n whose code
+// is not rejected by avoid, where the code is m zero-padded to width digits
+// followed by its checksum digit, as produced by AppendChecksumIntWidth. It
+// lets an issuance loop skip reserved or already-issued codes. A nil avoid
+// rejects nothing.
+//
+// NextValidAfter walks the integers one at a time, so an avoid function that
+// rejects long runs makes it slow. It returns an error once the candidates
+// no longer fit in width digits.
+func NextValidAfter(n int64, width int, avoid func(string) bool) (string, error) {
+    if n < 0 {
+        return "", errors.New("negative numbers cannot be zero-padded")
+    }
+    if width <= 0 {
+        return "", errors.New("width must be positive")
+    }
+
+    for m := n; m >= 0; m++ {
+        padded := fmt.Sprintf("%0*d", width, m)
+        if len(padded) > width {
+            break
+        }
+        code, err := AppendChecksumString(padded)
+        if err != nil {
+            return "", err
+        }
+        if avoid == nil || !avoid(code) {
+            return code, nil
+        }
+    }
+    return "", fmt.Errorf("no code at or after %d fits in %d digits", n, width)
+}
+
+// GenerateBlock returns count codes for consecutive integers starting at
+// start, each zero-padded to width digits with its checksum digit appended,
+// skipping any code that skip rejects, as NextValidAfter does. A nil skip
+// rejects nothing; pass a function wrapping IsTrivial to leave out
+// all-same and sequential codes. The output depends only on the arguments.
+// It returns an error if the block runs past width digits.
+func GenerateBlock(start int64, count, width int, skip func(full string) bool) ([]string, error) {
+    if count < 0 {
+        return nil, errors.New("count must not be negative")
+    }
+
+    codes := make([]string, 0, count)
+    next := start
+    for len(codes) < count {
+        code, err := NextValidAfter(next, width, skip)
+        if err != nil {
+            return nil, err
+        }
+        codes = append(codes, code)
+
+        issued, err := strconv.ParseInt(code[:width], 10, 64)
+        if err != nil {
+            return nil, err
+        }
+        next = issued + 1
+    }
+    return codes, nil
+}
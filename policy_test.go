@@ -0,0 +1,234 @@
+// FilePath: policy_test.go
+
+package verhoeff
+
+import (
+    "errors"
+    "math/rand"
+    "strings"
+    "testing"
+)
+
+func TestIsTrivial(t *testing.T) {
+    tests := []struct {
+        name     string
+        input    string
+        expected bool
+    }{
+        {"All same", "111111111", true},
+        {"All zeros", "0000", true},
+        {"Ascending", "123456", true},
+        {"Ascending with wrap", "7890123", true},
+        {"Descending", "987654", true},
+        {"Descending with wrap", "2109", true},
+        {"Single digit", "5", true},
+        {"Ordinary number", "284619", false},
+        {"Almost ascending", "123457", false},
+        {"Empty input", "", false},
+        {"Non-digit input", "aaaa", false},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            if got := IsTrivial(tt.input); got != tt.expected {
+                t.Errorf("IsTrivial(%q) = %v, want %v",
+                    tt.input, got, tt.expected)
+            }
+        })
+    }
+}
+
+func TestGenerateNonTrivial(t *testing.T) {
+    rng := rand.New(rand.NewSource(42))
+
+    for i := 0; i < 100; i++ {
+        full, err := GenerateNonTrivial(rng, 2)
+        if err != nil {
+            t.Fatalf("GenerateNonTrivial() error = %v", err)
+        }
+        if len(full) != 3 {
+            t.Fatalf("GenerateNonTrivial() = %q, want 3 digits", full)
+        }
+        if IsTrivial(full[:2]) {
+            t.Errorf("GenerateNonTrivial() returned trivial base %q", full)
+        }
+        valid, err := ValidateString(full)
+        if err != nil || !valid {
+            t.Errorf("GenerateNonTrivial() returned invalid %q", full)
+        }
+    }
+
+    t.Run("Deterministic for a seed", func(t *testing.T) {
+        a, _ := GenerateNonTrivial(rand.New(rand.NewSource(1)), 12)
+        b, _ := GenerateNonTrivial(rand.New(rand.NewSource(1)), 12)
+        if a != b {
+            t.Errorf("GenerateNonTrivial() not deterministic: %q vs %q", a, b)
+        }
+    })
+
+    t.Run("Length too short", func(t *testing.T) {
+        if _, err := GenerateNonTrivial(rng, 1); err == nil {
+            t.Errorf("GenerateNonTrivial() expected error for length 1")
+        }
+    })
+}
+
+func TestValidateWithPrefix(t *testing.T) {
+    tests := []struct {
+        name        string
+        input       string
+        prefix      string
+        expected    bool
+        expectedErr error
+    }{
+        {"Valid with prefix", "9112347", "91", true, nil},
+        {"Empty prefix", "123451", "", true, nil},
+        {"Prefix mismatch", "123451", "91", false, ErrPrefixMismatch},
+        {"Checksum mismatch", "9112344", "91", false, ErrChecksumMismatch},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            got, err := ValidateWithPrefix(tt.input, tt.prefix)
+
+            if !errors.Is(err, tt.expectedErr) {
+                t.Errorf("ValidateWithPrefix() error = %v, want %v",
+                    err, tt.expectedErr)
+            }
+
+            if got != tt.expected {
+                t.Errorf("ValidateWithPrefix() = %v, want %v",
+                    got, tt.expected)
+            }
+        })
+    }
+
+    t.Run("Non-digit input", func(t *testing.T) {
+        if _, err := ValidateWithPrefix("91a", "91"); err == nil {
+            t.Errorf("ValidateWithPrefix() expected error")
+        }
+        if _, err := ValidateWithPrefix("9112347", "9a"); err == nil {
+            t.Errorf("ValidateWithPrefix() expected error for bad prefix")
+        }
+    })
+}
+
+func TestSharesBase(t *testing.T) {
+    tests := []struct {
+        name     string
+        a        string
+        b        string
+        expected bool
+        hasError bool
+    }{
+        {"Same number", "2363", "2363", true, false},
+        {"Different check digit", "2363", "2364", true, false},
+        {"Different payload", "2363", "2463", false, false},
+        {"Single digits", "1", "7", true, false},
+        {"Different lengths", "2363", "23630", false, true},
+        {"Non-digit input", "23a3", "2363", false, true},
+        {"Empty input", "", "", false, true},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            got, err := SharesBase(tt.a, tt.b)
+
+            if (err != nil) != tt.hasError {
+                t.Errorf("SharesBase() error = %v, wantErr %v",
+                    err, tt.hasError)
+                return
+            }
+
+            if got != tt.expected {
+                t.Errorf("SharesBase() = %v, want %v", got, tt.expected)
+            }
+        })
+    }
+}
+
+func TestNextValidAfter(t *testing.T) {
+    reserved := map[string]bool{"00122": true, "00133": true}
+    tests := []struct {
+        name     string
+        n        int64
+        width    int
+        avoid    func(string) bool
+        expected string
+        hasError bool
+    }{
+        {"Nothing avoided", 12, 4, nil, "00122", false},
+        {"Skips reserved codes", 12, 4,
+            func(s string) bool { return reserved[s] }, "00146", false},
+        {"Avoid returns false", 236, 4,
+            func(string) bool { return false }, "02366", false},
+        {"Last value that fits", 99, 2, nil, "994", false},
+        {"Runs out of width", 98, 2,
+            func(string) bool { return true }, "", true},
+        {"Start does not fit", 100, 2, nil, "", true},
+        {"Negative start", -1, 4, nil, "", true},
+        {"Zero width", 12, 0, nil, "", true},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            got, err := NextValidAfter(tt.n, tt.width, tt.avoid)
+
+            if (err != nil) != tt.hasError {
+                t.Errorf("NextValidAfter() error = %v, wantErr %v",
+                    err, tt.hasError)
+                return
+            }
+
+            if got != tt.expected {
+                t.Errorf("NextValidAfter() = %v, want %v", got, tt.expected)
+            }
+        })
+    }
+}
+
+func TestGenerateBlock(t *testing.T) {
+    trivialPayload := func(full string) bool { return IsTrivial(full[:len(full)-1]) }
+
+    tests := []struct {
+        name     string
+        start    int64
+        count    int
+        width    int
+        skip     func(string) bool
+        expected []string
+        hasError bool
+    }{
+        {"Consecutive", 8, 4, 4, nil,
+            []string{"00082", "00095", "00105", "00114"}, false},
+        {"Skips rejected codes", 8, 5, 4,
+            func(s string) bool { return strings.HasSuffix(s, "2") },
+            []string{"00095", "00105", "00114", "00133", "00146"}, false},
+        {"Skips trivial payloads", 0, 2, 2, trivialPayload,
+            []string{"023", "034"}, false},
+        {"Zero count", 8, 0, 4, nil, []string{}, false},
+        {"Runs past width", 98, 3, 2, nil, nil, true},
+        {"Negative count", 8, -1, 4, nil, nil, true},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            got, err := GenerateBlock(tt.start, tt.count, tt.width, tt.skip)
+
+            if (err != nil) != tt.hasError {
+                t.Errorf("GenerateBlock() error = %v, wantErr %v",
+                    err, tt.hasError)
+                return
+            }
+
+            if len(got) != len(tt.expected) {
+                t.Fatalf("GenerateBlock() = %v, want %v", got, tt.expected)
+            }
+            for i := range got {
+                if got[i] != tt.expected[i] {
+                    t.Fatalf("GenerateBlock() = %v, want %v", got, tt.expected)
+                }
+            }
+        })
+    }
+}
@@ -0,0 +1,77 @@
+// FilePath: records.go
+
+package verhoeff
+
+import (
+    "fmt"
+    "unicode/utf8"
+)
+
+// field returns record[start:start+length], or an error if that range does
+// not lie within record.
+func field(record string, start, length int) (string, error) {
+    // start+length can overflow, so compare against len(record)-length.
+    if start < 0 || length <= 0 || length > len(record) || start > len(record)-length {
+        return "", fmt.Errorf("field of length %d at %d out of bounds for record of length %d",
+            length, start, len(record))
+    }
+    return record[start : start+length], nil
+}
+
+// ValidateAtOffset validates the number stored in record at byte offset
+// start and spanning length bytes, as found in fixed-width flat files. The
+// last byte of the field is the checksum digit.
+func ValidateAtOffset(record string, start, length int) (bool, error) {
+    value, err := field(record, start, length)
+    if err != nil {
+        return false, err
+    }
+    return ValidateString(value)
+}
+
+// ValidateAtOffsetBatch applies ValidateAtOffset to every record and returns
+// the 0-based indices of the records whose field fails validation, including
+// fields that contain non-digit characters. It returns an error if the field
+// does not fit in a record.
+func ValidateAtOffsetBatch(records []string, start, length int) ([]int, error) {
+    failed := []int{}
+    for i, record := range records {
+        value, err := field(record, start, length)
+        if err != nil {
+            return nil, fmt.Errorf("record %d: %w", i, err)
+        }
+        valid, err := ValidateString(value)
+        if err != nil || !valid {
+            failed = append(failed, i)
+        }
+    }
+    return failed, nil
+}
+
+// ValidateSplitFields validates a record whose check digit is stored apart
+// from its payload: the payload is the payloadLen bytes at payloadStart and
+// the check digit is the single byte at checkPos, with unrelated data
+// allowed in between. It returns an error if either field is out of bounds
+// or contains a non-digit.
+func ValidateSplitFields(s string, payloadStart, payloadLen, checkPos int) (bool, error) {
+    payload, err := field(s, payloadStart, payloadLen)
+    if err != nil {
+        return false, err
+    }
+    check, err := field(s, checkPos, 1)
+    if err != nil {
+        return false, err
+    }
+
+    digit := check[0] - '0'
+    if digit > 9 {
+        r, _ := utf8.DecodeRuneInString(s[checkPos:])
+        return false, fmt.Errorf("check character is not a digit: %w",
+            &InvalidCharError{Char: r, Offset: checkPos})
+    }
+    checksum, err := GenerateFromString(payload)
+    if err != nil {
+        return false, err
+    }
+    return checksum == int(digit), nil
+}
@@ -0,0 +1,135 @@
+// FilePath: records_test.go
+
+package verhoeff
+
+import (
+    "errors"
+    "math"
+    "testing"
+)
+
+func TestValidateAtOffset(t *testing.T) {
+    tests := []struct {
+        name     string
+        record   string
+        start    int
+        length   int
+        expected bool
+        hasError bool
+    }{
+        {"Field in the middle", "ACCT123451  X", 4, 6, true, false},
+        {"Field at start", "2363ABCD", 0, 4, true, false},
+        {"Invalid field", "ACCT123450  X", 4, 6, false, false},
+        {"Non-digit field", "ACCT12a451  X", 4, 6, false, true},
+        {"Past end", "ACCT1234", 4, 6, false, true},
+        {"Negative start", "2363", -1, 4, false, true},
+        {"Zero length", "2363", 0, 0, false, true},
+        {"Huge start", "2363", math.MaxInt, 1, false, true},
+        {"Huge length", "2363", 1, math.MaxInt, false, true},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            got, err := ValidateAtOffset(tt.record, tt.start, tt.length)
+
+            if (err != nil) != tt.hasError {
+                t.Errorf("ValidateAtOffset() error = %v, wantErr %v",
+                    err, tt.hasError)
+                return
+            }
+
+            if got != tt.expected {
+                t.Errorf("ValidateAtOffset() = %v, want %v", got, tt.expected)
+            }
+        })
+    }
+}
+
+func TestValidateAtOffsetBatch(t *testing.T) {
+    records := []string{
+        "AB2363Z",
+        "AB2364Z",
+        "AB23a3Z",
+        "AB1234Z",
+    }
+
+    got, err := ValidateAtOffsetBatch(records, 2, 4)
+    if err != nil {
+        t.Fatalf("ValidateAtOffsetBatch() error = %v", err)
+    }
+
+    expected := []int{1, 2, 3}
+    if len(got) != len(expected) {
+        t.Fatalf("ValidateAtOffsetBatch() = %v, want %v", got, expected)
+    }
+    for i := range got {
+        if got[i] != expected[i] {
+            t.Errorf("ValidateAtOffsetBatch() = %v, want %v", got, expected)
+            break
+        }
+    }
+
+    t.Run("Short record", func(t *testing.T) {
+        _, err := ValidateAtOffsetBatch([]string{"AB2363Z", "AB23"}, 2, 4)
+        if err == nil {
+            t.Errorf("ValidateAtOffsetBatch() expected error for short record")
+        }
+    })
+
+    t.Run("Huge start", func(t *testing.T) {
+        _, err := ValidateAtOffsetBatch(records, math.MaxInt, 4)
+        if err == nil {
+            t.Errorf("ValidateAtOffsetBatch() expected error for huge start")
+        }
+    })
+}
+
+func TestValidateSplitFields(t *testing.T) {
+    tests := []struct {
+        name         string
+        record       string
+        payloadStart int
+        payloadLen   int
+        checkPos     int
+        expected     bool
+        hasError     bool
+    }{
+        {"Check digit apart", "12345678901XYZ 0", 0, 11, 15, true, false},
+        {"Wrong check digit", "12345678901XYZ 7", 0, 11, 15, false, false},
+        {"Check digit before payload", "3--236", 3, 3, 0, true, false},
+        {"Check character not a digit", "12345678901XYZ X", 0, 11, 15, false, true},
+        {"Payload not digits", "1234567890AXYZ 0", 0, 11, 15, false, true},
+        {"Check past end", "12345678901XYZ", 0, 11, 15, false, true},
+        {"Payload past end", "12345", 0, 11, 2, false, true},
+        {"Negative check position", "12345678901XYZ 0", 0, 11, -1, false, true},
+        {"Huge check position", "12345678901XYZ 0", 0, 11, math.MaxInt, false, true},
+        {"Huge payload start", "12345678901XYZ 0", math.MaxInt, 11, 15, false, true},
+        {"Huge payload length", "12345678901XYZ 0", 0, math.MaxInt, 15, false, true},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            got, err := ValidateSplitFields(tt.record, tt.payloadStart,
+                tt.payloadLen, tt.checkPos)
+
+            if (err != nil) != tt.hasError {
+                t.Errorf("ValidateSplitFields() error = %v, wantErr %v",
+                    err, tt.hasError)
+                return
+            }
+
+            if got != tt.expected {
+                t.Errorf("ValidateSplitFields() = %v, want %v", got, tt.expected)
+            }
+        })
+    }
+
+    t.Run("Non-digit check wraps ErrNonDigit", func(t *testing.T) {
+        _, err := ValidateSplitFields("12345678901XYZ X", 0, 11, 15)
+        var charErr *InvalidCharError
+        if !errors.As(err, &charErr) || charErr.Char != 'X' || charErr.Offset != 15 ||
+            !errors.Is(err, ErrNonDigit) {
+            t.Errorf("ValidateSplitFields() error = %v, want 'X' at offset 15", err)
+        }
+    })
+}
@@ -0,0 +1,69 @@
+// FilePath: reference.go
+
+package verhoeff
+
+// This file holds a second, deliberately naive implementation of the
+// Verhoeff check digit, written the way the algorithm is usually presented
+// rather than for speed. It derives everything from the definitions instead
+// of the lookup tables in verhoeff.go, so the tests can use it to check both
+// the tables and the optimised code. It is not used outside the tests.
+
+// verhoeffSigma is the permutation the algorithm applies to a digit once
+// per position, in one-line notation: digit i maps to verhoeffSigma[i].
+var verhoeffSigma = [10]int{1, 5, 7, 6, 2, 8, 3, 0, 9, 4}
+
+// dihedralMultiply multiplies two elements of the dihedral group D5, with
+// 0-4 standing for the rotations r^0 to r^4 and 5-9 for the reflections
+// r^0·s to r^4·s. Since s·r = r^-1·s, moving a rotation past s negates it.
+func dihedralMultiply(a, b int) int {
+    mod5 := func(n int) int { return ((n % 5) + 5) % 5 }
+    switch {
+    case a < 5 && b < 5:
+        // r^a · r^b = r^(a+b)
+        return mod5(a + b)
+    case a < 5:
+        // r^a · r^j·s = r^(a+j)·s
+        return 5 + mod5(a+b-5)
+    case b < 5:
+        // r^i·s · r^b = r^(i-b)·s
+        return 5 + mod5(a-5-b)
+    default:
+        // r^i·s · r^j·s = r^(i-j)
+        return mod5((a - 5) - (b - 5))
+    }
+}
+
+// dihedralInverse returns the inverse of an element of D5: rotations
+// invert to the opposite rotation and reflections are their own inverse.
+func dihedralInverse(a int) int {
+    if a < 5 {
+        return (5 - a) % 5
+    }
+    return a
+}
+
+// permute applies verhoeffSigma to digit n times.
+func permute(n, digit int) int {
+    for i := 0; i < n; i++ {
+        digit = verhoeffSigma[digit]
+    }
+    return digit
+}
+
+// calculateChecksumRef is the reference counterpart of calculateChecksum.
+// It reverses the digits so the check digit would sit at position 0, maps
+// the digit at position i through the permutation i mod 8 times, multiplies
+// the results together in D5 and returns the inverse of the product.
+func calculateChecksumRef(digits []int) int {
+    reversed := []int{}
+    for i := len(digits) - 1; i >= 0; i-- {
+        reversed = append(reversed, digits[i])
+    }
+
+    product := 0
+    for i, digit := range reversed {
+        position := i + 1
+        product = dihedralMultiply(product, permute(position%8, digit))
+    }
+    return dihedralInverse(product)
+}
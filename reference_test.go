@@ -0,0 +1,66 @@
+// FilePath: reference_test.go
+
+package verhoeff
+
+import (
+    "math/rand"
+    "strings"
+    "testing"
+)
+
+func TestReferenceTables(t *testing.T) {
+    for a := 0; a < 10; a++ {
+        for b := 0; b < 10; b++ {
+            if got := dihedralMultiply(a, b); got != d[a][b] {
+                t.Errorf("dihedralMultiply(%d, %d) = %d, want d[%d][%d] = %d", a, b, got, a, b, d[a][b])
+            }
+        }
+        if got := dihedralInverse(a); got != inv[a] {
+            t.Errorf("dihedralInverse(%d) = %d, want inv[%d] = %d", a, got, a, inv[a])
+        }
+    }
+    for k := 0; k < 8; k++ {
+        for digit := 0; digit < 10; digit++ {
+            if got := permute(k, digit); got != p[k][digit] {
+                t.Errorf("permute(%d, %d) = %d, want p[%d][%d] = %d", k, digit, got, k, digit, p[k][digit])
+            }
+        }
+    }
+}
+
+func TestCalculateChecksumRef(t *testing.T) {
+    rng := rand.New(rand.NewSource(13))
+    for n := 0; n < 2000; n++ {
+        digits := make([]int, rng.Intn(40))
+        for i := range digits {
+            digits[i] = rng.Intn(10)
+        }
+
+        want := calculateChecksum(digits)
+        if got := calculateChecksumRef(digits); got != want {
+            t.Fatalf("calculateChecksumRef(%v) = %d, calculateChecksum() = %d", digits, got, want)
+        }
+    }
+}
+
+// BenchmarkChecksumOptimized benchmarks the table-driven calculateChecksum
+// on a 900-digit input.
+func BenchmarkChecksumOptimized(b *testing.B) {
+    digits, _ := stringToDigits(strings.Repeat("123456789", 100))
+
+    b.ResetTimer()
+    for i := 0; i < b.N; i++ {
+        _ = calculateChecksum(digits)
+    }
+}
+
+// BenchmarkChecksumReference benchmarks calculateChecksumRef on the same
+// input, for comparison.
+func BenchmarkChecksumReference(b *testing.B) {
+    digits, _ := stringToDigits(strings.Repeat("123456789", 100))
+
+    b.ResetTimer()
+    for i := 0; i < b.N; i++ {
+        _ = calculateChecksumRef(digits)
+    }
+}
@@ -0,0 +1,60 @@
+// FilePath: scan.go
+
+package verhoeff
+
+// Match is a valid number found in free text by FindValid.
+type Match struct {
+    // Start is the byte offset of the first digit in the text.
+    Start int
+    // End is the byte offset just past the last digit, so the number is
+    // text[Start:End].
+    End int
+    // Number is the matched digits, check digit included.
+    Number string
+}
+
+// FindValid scans text for runs of ASCII digits and reports every substring
+// of a run that is between minLen and maxLen digits long and validates.
+// Matches never extend across a non-digit, but within a run all
+// qualifying substrings are reported, including overlapping and nested
+// ones, ordered by start offset and then by length. Since about one in ten
+// arbitrary digit strings validates by chance, callers scanning for
+// fixed-length IDs should set minLen and maxLen to that length.
+//
+// Each start position extends a running checksum state one digit at a time
+// instead of re-checksumming every window, so a run of n digits costs
+// O(n*maxLen). A minLen below 1 is treated as 1, and if maxLen is less than
+// minLen there are no matches.
+func FindValid(text string, minLen, maxLen int) []Match {
+    if minLen < 1 {
+        minLen = 1
+    }
+    matches := []Match{}
+    if maxLen < minLen {
+        return matches
+    }
+
+    for runStart := 0; runStart < len(text); {
+        if text[runStart]-'0' > 9 {
+            runStart++
+            continue
+        }
+        runEnd := runStart
+        for runEnd < len(text) && text[runEnd]-'0' <= 9 {
+            runEnd++
+        }
+
+        for start := runStart; start+minLen <= runEnd; start++ {
+            var state forwardState
+            for end := start; end < runEnd && end-start < maxLen; end++ {
+                state.push(int(text[end] - '0'))
+                if end+1-start >= minLen && state.valid() {
+                    matches = append(matches,
+                        Match{Start: start, End: end + 1, Number: text[start : end+1]})
+                }
+            }
+        }
+        runStart = runEnd
+    }
+    return matches
+}
@@ -0,0 +1,89 @@
+// FilePath: scan_test.go
+
+package verhoeff
+
+import (
+    "math/rand"
+    "testing"
+)
+
+func TestFindValid(t *testing.T) {
+    tests := []struct {
+        name     string
+        text     string
+        minLen   int
+        maxLen   int
+        expected []Match
+    }{
+        {
+            "Fixed length", "id=123451, ref 2363; x", 6, 6,
+            []Match{{3, 9, "123451"}},
+        },
+        {
+            "Length range", "id=123451, ref 2363; x", 4, 6,
+            []Match{{3, 9, "123451"}, {15, 19, "2363"}},
+        },
+        {
+            "Nested matches", "a1234510", 4, 8,
+            []Match{{1, 7, "123451"}, {1, 8, "1234510"}},
+        },
+        {
+            "Does not cross non-digits", "1234-51", 6, 6,
+            []Match{},
+        },
+        {"No digits", "no numbers here", 1, 10, []Match{}},
+        {"Empty bounds", "123451", 6, 5, []Match{}},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            got := FindValid(tt.text, tt.minLen, tt.maxLen)
+
+            if len(got) != len(tt.expected) {
+                t.Fatalf("FindValid() = %v, want %v", got, tt.expected)
+            }
+            for i := range got {
+                if got[i] != tt.expected[i] {
+                    t.Fatalf("FindValid() = %v, want %v", got, tt.expected)
+                }
+            }
+        })
+    }
+}
+
+// TestFindValidMatchesBruteForce compares FindValid with validating every
+// window separately.
+func TestFindValidMatchesBruteForce(t *testing.T) {
+    rng := rand.New(rand.NewSource(5))
+    alphabet := "0123456789012345678901234567890123456789 -x"
+
+    for round := 0; round < 50; round++ {
+        text := make([]byte, 200)
+        for i := range text {
+            text[i] = alphabet[rng.Intn(len(alphabet))]
+        }
+        minLen, maxLen := 3, 9
+
+        var expected []Match
+        for start := 0; start < len(text); start++ {
+            for end := start + minLen; end <= len(text) && end-start <= maxLen; end++ {
+                window := string(text[start:end])
+                if valid, err := ValidateString(window); err == nil && valid {
+                    expected = append(expected, Match{start, end, window})
+                }
+            }
+        }
+
+        got := FindValid(string(text), minLen, maxLen)
+        if len(got) != len(expected) {
+            t.Fatalf("FindValid(%q) found %d matches, want %d",
+                text, len(got), len(expected))
+        }
+        for i := range got {
+            if got[i] != expected[i] {
+                t.Fatalf("FindValid(%q)[%d] = %v, want %v",
+                    text, i, got[i], expected[i])
+            }
+        }
+    }
+}
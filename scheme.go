@@ -0,0 +1,33 @@
+// FilePath: scheme.go
+
+package verhoeff
+
+// Scheme gives the check-digit operations a method form, so code that
+// chooses between check-digit schemes can call them uniformly through a
+// value. Scheme has no state: its zero value, a nil *Scheme and Standard
+// all implement standard base-10 Verhoeff and behave identically.
+type Scheme struct{}
+
+// Standard is the standard base-10 Verhoeff scheme, for writing
+// verhoeff.Standard.Generate(s). It is shared and read-only; since Scheme
+// has no fields there is nothing to modify, and it is safe for concurrent
+// use.
+var Standard = &Scheme{}
+
+// Generate calculates the check digit for a string of digits, like
+// GenerateFromString.
+func (s *Scheme) Generate(input string) (int, error) {
+    return GenerateFromString(input)
+}
+
+// Validate checks a string of digits ending in its check digit, like
+// ValidateString.
+func (s *Scheme) Validate(input string) (bool, error) {
+    return ValidateString(input)
+}
+
+// AppendChecksum appends the check digit to a string of digits, like
+// AppendChecksumString.
+func (s *Scheme) AppendChecksum(input string) (string, error) {
+    return AppendChecksumString(input)
+}
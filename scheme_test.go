@@ -0,0 +1,37 @@
+// FilePath: scheme_test.go
+
+package verhoeff
+
+import (
+    "testing"
+)
+
+func TestScheme(t *testing.T) {
+    var zero Scheme
+    var nilScheme *Scheme
+    schemes := map[string]*Scheme{
+        "Standard":   Standard,
+        "Zero value": &zero,
+        "Nil":        nilScheme,
+    }
+
+    for name, scheme := range schemes {
+        t.Run(name, func(t *testing.T) {
+            if got, err := scheme.Generate("12345"); err != nil || got != 1 {
+                t.Errorf("Generate() = %v, %v, want 1", got, err)
+            }
+            if got, err := scheme.Validate("123451"); err != nil || !got {
+                t.Errorf("Validate() = %v, %v, want true", got, err)
+            }
+            if got, err := scheme.Validate("123450"); err != nil || got {
+                t.Errorf("Validate() = %v, %v, want false", got, err)
+            }
+            if got, err := scheme.AppendChecksum("236"); err != nil || got != "2363" {
+                t.Errorf("AppendChecksum() = %v, %v, want 2363", got, err)
+            }
+            if _, err := scheme.Generate("12a"); err == nil {
+                t.Errorf("Generate() expected error for non-digit input")
+            }
+        })
+    }
+}
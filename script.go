@@ -0,0 +1,96 @@
+// FilePath: script.go
+
+package verhoeff
+
+import (
+    "fmt"
+    "unicode"
+)
+
+// digitScripts are the scripts, besides ASCII and fullwidth digits, whose
+// decimal digits ValidateWithScriptReport accepts.
+var digitScripts = []struct {
+    name  string
+    table *unicode.RangeTable
+}{
+    {"Arabic", unicode.Arabic},
+    {"Devanagari", unicode.Devanagari},
+    {"Bengali", unicode.Bengali},
+    {"Gurmukhi", unicode.Gurmukhi},
+    {"Gujarati", unicode.Gujarati},
+    {"Oriya", unicode.Oriya},
+    {"Tamil", unicode.Tamil},
+    {"Telugu", unicode.Telugu},
+    {"Kannada", unicode.Kannada},
+    {"Malayalam", unicode.Malayalam},
+    {"Thai", unicode.Thai},
+    {"Lao", unicode.Lao},
+    {"Tibetan", unicode.Tibetan},
+    {"Myanmar", unicode.Myanmar},
+    {"Khmer", unicode.Khmer},
+    {"Mongolian", unicode.Mongolian},
+}
+
+// digitScript returns the name of the script r belongs to and its value as
+// a digit, or ok false if r is not a decimal digit of a supported script.
+// ASCII digits are reported as "Latin" and U+FF10 through U+FF19 as
+// "Fullwidth", although Unicode assigns both to the Common script.
+func digitScript(r rune) (script string, digit int, ok bool) {
+    switch {
+    case r >= '0' && r <= '9':
+        return "Latin", int(r - '0'), true
+    case r >= '０' && r <= '９':
+        return "Fullwidth", int(r - '０'), true
+    case !unicode.Is(unicode.Nd, r):
+        return "", 0, false
+    }
+
+    for _, ds := range digitScripts {
+        if unicode.Is(ds.table, r) {
+            // Decimal digits are encoded in runs of ten from zero to nine,
+            // some of them back to back, so count from the start of the
+            // whole span of digits r sits in.
+            start := r
+            for unicode.Is(unicode.Nd, start-1) {
+                start--
+            }
+            return ds.name, int(r-start) % 10, true
+        }
+    }
+    return "", 0, false
+}
+
+// ValidateWithScriptReport validates s, which may be written in the decimal
+// digits of several scripts, and also reports which scripts the digits came
+// from, in order of first appearance. Supported are ASCII ("Latin"),
+// fullwidth ("Fullwidth") and the native digits of the scripts of South and
+// South-East Asia and of Arabic, under their Unicode script names.
+//
+// A number whose digits come from more than one script, such as ASCII
+// digits with a Devanagari one spliced in, is a common spoofing trick, so
+// treat len(scripts) > 1 as suspect even when valid is true. Digits of other
+// scripts are rejected with an error.
+func ValidateWithScriptReport(s string) (valid bool, scripts []string, err error) {
+    if s == "" {
+        return false, nil, ErrEmptyInput
+    }
+
+    digits := make([]int, 0, len(s))
+    scripts = []string{}
+    seen := map[string]bool{}
+    for _, r := range s {
+        script, digit, ok := digitScript(r)
+        if !ok {
+            if unicode.IsDigit(r) {
+                return false, nil, fmt.Errorf("digit %q is from an unsupported script", r)
+            }
+            return false, nil, ErrNonDigit
+        }
+        if !seen[script] {
+            seen[script] = true
+            scripts = append(scripts, script)
+        }
+        digits = append(digits, digit)
+    }
+    return validateChecksum(digits), scripts, nil
+}
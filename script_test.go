@@ -0,0 +1,47 @@
+// FilePath: script_test.go
+
+package verhoeff
+
+import (
+    "reflect"
+    "testing"
+)
+
+func TestValidateWithScriptReport(t *testing.T) {
+    tests := []struct {
+        name            string
+        input           string
+        expectedValid   bool
+        expectedScripts []string
+        hasError        bool
+    }{
+        {"ASCII", "2363", true, []string{"Latin"}, false},
+        {"ASCII wrong checksum", "2364", false, []string{"Latin"}, false},
+        {"Devanagari", "२३६३", true, []string{"Devanagari"}, false},
+        {"Arabic-Indic", "٢٣٦٣", true, []string{"Arabic"}, false},
+        {"Extended Arabic-Indic", "۲۳۶۳", true, []string{"Arabic"}, false},
+        {"Fullwidth", "２３６３", true, []string{"Fullwidth"}, false},
+        {"Mixed scripts", "23६3", true, []string{"Latin", "Devanagari"}, false},
+        {"Mixed scripts wrong checksum", "২23৪", false, []string{"Bengali", "Latin"}, false},
+        {"Unsupported script", "23\U0001D7D43", false, nil, true},
+        {"Non-digit", "23a3", false, nil, true},
+        {"Empty string", "", false, nil, true},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            valid, scripts, err := ValidateWithScriptReport(tt.input)
+
+            if (err != nil) != tt.hasError {
+                t.Errorf("ValidateWithScriptReport() error = %v, wantErr %v",
+                    err, tt.hasError)
+                return
+            }
+
+            if valid != tt.expectedValid || !reflect.DeepEqual(scripts, tt.expectedScripts) {
+                t.Errorf("ValidateWithScriptReport() = (%v, %v), want (%v, %v)",
+                    valid, scripts, tt.expectedValid, tt.expectedScripts)
+            }
+        })
+    }
+}
@@ -0,0 +1,48 @@
+// FilePath: sql.go
+
+package verhoeff
+
+import (
+    "database/sql"
+    "fmt"
+)
+
+// GenerateFromRows reads the number in column colIndex of every row, works
+// out its checksum digit and passes both to fn, typically to issue an UPDATE
+// during a backfill. It stops at the first scan, parse or fn error and
+// returns it. NULL values are reported as errors. The caller still owns rows
+// and should close it.
+func GenerateFromRows(rows *sql.Rows, colIndex int, fn func(id string, check int) error) error {
+    columns, err := rows.Columns()
+    if err != nil {
+        return err
+    }
+    if colIndex < 0 || colIndex >= len(columns) {
+        return fmt.Errorf("column index %d out of range for %d columns",
+            colIndex, len(columns))
+    }
+
+    var id sql.NullString
+    dest := make([]any, len(columns))
+    for i := range dest {
+        dest[i] = new(any)
+    }
+    dest[colIndex] = &id
+
+    for row := 0; rows.Next(); row++ {
+        if err := rows.Scan(dest...); err != nil {
+            return fmt.Errorf("row %d: %w", row, err)
+        }
+        if !id.Valid {
+            return fmt.Errorf("row %d: column %q is NULL", row, columns[colIndex])
+        }
+        check, err := GenerateFromString(id.String)
+        if err != nil {
+            return fmt.Errorf("row %d: %w", row, err)
+        }
+        if err := fn(id.String, check); err != nil {
+            return err
+        }
+    }
+    return rows.Err()
+}
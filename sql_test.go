@@ -0,0 +1,153 @@
+// FilePath: sql_test.go
+
+package verhoeff
+
+import (
+    "database/sql"
+    "database/sql/driver"
+    "errors"
+    "io"
+    "testing"
+)
+
+// fakeTables maps a query string to the rows the fake driver returns for it.
+// The first row holds the column names.
+var fakeTables = map[string][][]driver.Value{
+    "ids": {
+        {"pk", "id"},
+        {int64(1), "236"},
+        {int64(2), "12345"},
+    },
+    "bad": {
+        {"pk", "id"},
+        {int64(1), "236"},
+        {int64(2), "12a45"},
+    },
+    "null": {
+        {"pk", "id"},
+        {int64(1), nil},
+    },
+}
+
+type fakeDriver struct{}
+
+func (fakeDriver) Open(string) (driver.Conn, error) { return fakeConn{}, nil }
+
+type fakeConn struct{}
+
+func (fakeConn) Prepare(query string) (driver.Stmt, error) {
+    return fakeStmt{query}, nil
+}
+func (fakeConn) Close() error { return nil }
+func (fakeConn) Begin() (driver.Tx, error) {
+    return nil, errors.New("transactions not supported")
+}
+
+type fakeStmt struct{ query string }
+
+func (fakeStmt) Close() error  { return nil }
+func (fakeStmt) NumInput() int { return 0 }
+func (fakeStmt) Exec([]driver.Value) (driver.Result, error) {
+    return nil, errors.New("exec not supported")
+}
+func (s fakeStmt) Query([]driver.Value) (driver.Rows, error) {
+    table := fakeTables[s.query]
+    return &fakeRows{table: table, next: 1}, nil
+}
+
+type fakeRows struct {
+    table [][]driver.Value
+    next  int
+}
+
+func (r *fakeRows) Columns() []string {
+    columns := make([]string, len(r.table[0]))
+    for i, name := range r.table[0] {
+        columns[i] = name.(string)
+    }
+    return columns
+}
+func (r *fakeRows) Close() error { return nil }
+func (r *fakeRows) Next(dest []driver.Value) error {
+    if r.next >= len(r.table) {
+        return io.EOF
+    }
+    copy(dest, r.table[r.next])
+    r.next++
+    return nil
+}
+
+func init() {
+    sql.Register("verhoeff-fake", fakeDriver{})
+}
+
+func queryFake(t *testing.T, query string) *sql.Rows {
+    t.Helper()
+    db, err := sql.Open("verhoeff-fake", "")
+    if err != nil {
+        t.Fatalf("sql.Open() error = %v", err)
+    }
+    t.Cleanup(func() { db.Close() })
+
+    rows, err := db.Query(query)
+    if err != nil {
+        t.Fatalf("Query() error = %v", err)
+    }
+    t.Cleanup(func() { rows.Close() })
+    return rows
+}
+
+func TestGenerateFromRows(t *testing.T) {
+    t.Run("Calls fn per row", func(t *testing.T) {
+        got := map[string]int{}
+        err := GenerateFromRows(queryFake(t, "ids"), 1,
+            func(id string, check int) error {
+                got[id] = check
+                return nil
+            })
+        if err != nil {
+            t.Fatalf("GenerateFromRows() error = %v", err)
+        }
+        if len(got) != 2 || got["236"] != 3 || got["12345"] != 1 {
+            t.Errorf("GenerateFromRows() produced %v", got)
+        }
+    })
+
+    t.Run("Stops on parse error", func(t *testing.T) {
+        calls := 0
+        err := GenerateFromRows(queryFake(t, "bad"), 1,
+            func(string, int) error {
+                calls++
+                return nil
+            })
+        if err == nil || calls != 1 {
+            t.Errorf("GenerateFromRows() = %v after %d calls, want error after 1",
+                err, calls)
+        }
+    })
+
+    t.Run("Propagates fn error", func(t *testing.T) {
+        sentinel := errors.New("update failed")
+        err := GenerateFromRows(queryFake(t, "ids"), 1,
+            func(string, int) error { return sentinel })
+        if !errors.Is(err, sentinel) {
+            t.Errorf("GenerateFromRows() error = %v, want %v", err, sentinel)
+        }
+    })
+
+    t.Run("NULL value", func(t *testing.T) {
+        err := GenerateFromRows(queryFake(t, "null"), 1,
+            func(string, int) error { return nil })
+        if err == nil {
+            t.Errorf("GenerateFromRows() expected error for NULL")
+        }
+    })
+
+    t.Run("Column out of range", func(t *testing.T) {
+        err := GenerateFromRows(queryFake(t, "ids"), 2,
+            func(string, int) error { return nil })
+        if err == nil {
+            t.Errorf("GenerateFromRows() expected error for bad column")
+        }
+    })
+}
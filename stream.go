@@ -0,0 +1,471 @@
+// FilePath: stream.go
+
+package verhoeff
+
+import (
+    "bufio"
+    "errors"
+    "fmt"
+    "io"
+)
+
+// forwardState folds digits into the Verhoeff recurrence most-significant
+// digit first, which is the order they arrive in from a stream.
+//
+// The algorithm weights each digit by its distance from the right-hand end,
+// which is unknown until the input ends. Since the permutation table repeats
+// every 8 positions, forwardState keeps one running value per possible
+// position of the most recent digit: lane k holds the product of all digits
+// so far assuming the latest one sits at position k (0 = rightmost). Lane 0
+// is then the validation value and lane 1 the generation value.
+type forwardState [8]int
+
+// push folds the next digit (0-9) into the state.
+func (s *forwardState) push(digit int) {
+    prev := *s
+    for k := 0; k < 8; k++ {
+        s[k] = d[p[k][digit]][prev[(k+1)%8]]
+    }
+}
+
+// checksum returns the check digit for the digits pushed so far.
+func (s *forwardState) checksum() int {
+    return inv[s[1]]
+}
+
+// valid reports whether the digits pushed so far end in a correct check
+// digit.
+func (s *forwardState) valid() bool {
+    return s[0] == 0
+}
+
+// maxPackedState bounds the values pack can produce (10^8).
+const maxPackedState = 100000000
+
+// pack encodes the state as a single int, lane k in the k-th decimal
+// digit. The zero state packs to 0.
+func (s *forwardState) pack() int {
+    packed := 0
+    for k := 7; k >= 0; k-- {
+        packed = packed*10 + s[k]
+    }
+    return packed
+}
+
+// unpackState reverses pack, rejecting values outside the range pack can
+// produce. It does not check that the lanes are consistent with each other,
+// so a value in range that pack never returned unpacks to a junk state.
+func unpackState(packed int) (forwardState, error) {
+    var s forwardState
+    if packed < 0 || packed >= maxPackedState {
+        return s, fmt.Errorf("invalid checksum state: %d", packed)
+    }
+    for k := 0; k < 8; k++ {
+        s[k] = packed % 10
+        packed /= 10
+    }
+    return s, nil
+}
+
+// GenerateWithState folds the digits of s into a running checksum state and
+// returns the new state. Start with a state of 0 and feed the segments of a
+// number left to right; Finalize turns the last state into the check digit,
+// so GenerateWithState(0, "123") followed by GenerateWithState(c, "45") gives
+// the same check digit as GenerateFromString("12345").
+//
+// The state is not the c value of the textbook recurrence. That c depends on
+// each digit's distance from the end of the number, which is unknown until
+// the last segment arrives, so the state tracks c for all eight positions
+// the permutation table cycles through. Treat it as opaque and only pass
+// back values GenerateWithState returned: values outside [0, 10^8) are
+// rejected, but any other value is accepted and gives a meaningless result.
+func GenerateWithState(prevC int, s string) (newC int, err error) {
+    state, err := unpackState(prevC)
+    if err != nil {
+        return -1, err
+    }
+    digits, err := stringToDigits(s)
+    if err != nil {
+        return -1, err
+    }
+    for _, digit := range digits {
+        state.push(digit)
+    }
+    return state.pack(), nil
+}
+
+// Finalize returns the check digit for a state produced by
+// GenerateWithState, applying the inv lookup. It returns -1 if c is outside
+// the range of states; see GenerateWithState for values within it.
+func Finalize(c int) int {
+    state, err := unpackState(c)
+    if err != nil {
+        return -1
+    }
+    return state.checksum()
+}
+
+// PrefixChecksums returns the check digit for every prefix of s: element i
+// is the check digit for s[:i+1]. It makes one pass over s, carrying the
+// same state as GenerateWithState, so it suits validity indicators updated
+// as the user types.
+func PrefixChecksums(s string) ([]int, error) {
+    digits, err := stringToDigits(s)
+    if err != nil {
+        return nil, err
+    }
+
+    var state forwardState
+    checksums := make([]int, len(digits))
+    for i, digit := range digits {
+        state.push(digit)
+        checksums[i] = state.checksum()
+    }
+    return checksums, nil
+}
+
+// Checksummer calculates a check digit over ASCII digits written to it in
+// chunks, so numbers too large to hold in memory can be checksummed with
+// io.Copy. It implements io.Writer. Each digit is folded in as it is
+// written, using the same position tracking as GenerateWithState, so
+// nothing is buffered and the total length never needs to be known in
+// advance.
+type Checksummer struct {
+    state forwardState
+}
+
+// NewChecksummer returns a Checksummer with no digits written.
+func NewChecksummer() *Checksummer {
+    return &Checksummer{}
+}
+
+// Write folds the digits in b into the checksum. At the first non-digit
+// byte it stops and returns its index along with an error; the digits
+// before it have been folded in.
+func (c *Checksummer) Write(b []byte) (int, error) {
+    for i, ch := range b {
+        digit := ch - '0'
+        if digit > 9 {
+            return i, ErrNonDigit
+        }
+        c.state.push(int(digit))
+    }
+    return len(b), nil
+}
+
+// Sum returns the check digit for the digits written so far. It is 0 if
+// nothing has been written, as GenerateFromString("") is.
+func (c *Checksummer) Sum() int {
+    return c.state.checksum()
+}
+
+// Reset discards the digits written so far, so the Checksummer can be
+// reused for another number.
+func (c *Checksummer) Reset() {
+    c.state = forwardState{}
+}
+
+// PrefixComputer calculates check digits for numbers that share a fixed
+// prefix, such as an issuer prefix followed by a serial. The prefix is
+// folded in once by NewPrefixComputer, so each ForSuffix call only costs
+// the length of the suffix. A PrefixComputer is never modified after
+// creation and is safe for concurrent use.
+type PrefixComputer struct {
+    state forwardState
+}
+
+// NewPrefixComputer returns a PrefixComputer for numbers starting with
+// prefix.
+func NewPrefixComputer(prefix string) (*PrefixComputer, error) {
+    digits, err := stringToDigits(prefix)
+    if err != nil {
+        return nil, err
+    }
+
+    pc := &PrefixComputer{}
+    for _, digit := range digits {
+        pc.state.push(digit)
+    }
+    return pc, nil
+}
+
+// ForSuffix returns the check digit for the prefix followed by suffix, the
+// same as GenerateFromString(prefix + suffix).
+func (pc *PrefixComputer) ForSuffix(suffix string) (int, error) {
+    digits, err := stringToDigits(suffix)
+    if err != nil {
+        return -1, err
+    }
+
+    // Fold the suffix right to left as calculateChecksum does, then join
+    // the prefix through the lane that puts its last digit just left of
+    // the suffix. This costs one lookup per suffix digit instead of the
+    // eight a push takes.
+    c := 0
+    for i := 0; i < len(digits); i++ {
+        c = d[c][p[(i+1)%8][digits[len(digits)-1-i]]]
+    }
+    c = d[c][pc.state[(len(digits)+1)%8]]
+    return inv[c], nil
+}
+
+// ErrCancelled is returned when a progress callback asks for an operation
+// to stop.
+var ErrCancelled = errors.New("validation cancelled")
+
+// callbackInterval is how many digits ValidateWithCallback processes between
+// progress callbacks.
+const callbackInterval = 4096
+
+// ValidateWithCallback validates s like ValidateString while reporting
+// progress, for numbers long enough to warrant a progress indicator. cb is
+// called with the number of digits processed so far after every 4096
+// digits and once more after the last one, so it always sees len(s) on
+// success. Returning false from cb stops validation with ErrCancelled. A nil
+// cb is never called. Only ASCII digits are accepted.
+func ValidateWithCallback(s string, cb func(processed int) bool) (bool, error) {
+    if s == "" {
+        return false, ErrEmptyInput
+    }
+
+    var state forwardState
+    for i := 0; i < len(s); i++ {
+        digit := s[i] - '0'
+        if digit > 9 {
+            return false, ErrNonDigit
+        }
+        state.push(int(digit))
+
+        processed := i + 1
+        if cb != nil && (processed%callbackInterval == 0 || processed == len(s)) {
+            if !cb(processed) {
+                return false, ErrCancelled
+            }
+        }
+    }
+    return state.valid(), nil
+}
+
+// RollingWindow tracks the Verhoeff state of the last few digits of a
+// digit stream as it slides, for spotting valid-looking numbers as they go
+// by.
+//
+// The weight of every digit changes each time a digit arrives, and the
+// permutations involved do not respect the group operation, so there is no
+// constant-time update. Each Push recomputes over the buffered window
+// instead, costing O(size) per digit. The zero value is not usable; create
+// windows with NewRollingWindow.
+type RollingWindow struct {
+    buf   []int
+    start int // index of the oldest digit in buf once it is full
+    full  bool
+    state forwardState
+}
+
+// NewRollingWindow returns a window over the last size digits pushed.
+func NewRollingWindow(size int) (*RollingWindow, error) {
+    if size <= 0 {
+        return nil, errors.New("window size must be positive")
+    }
+    return &RollingWindow{buf: make([]int, 0, size)}, nil
+}
+
+// Push adds the next digit (0-9) to the window, dropping the oldest one once
+// the window is full.
+func (w *RollingWindow) Push(digit int) error {
+    if digit < 0 || digit > 9 {
+        return fmt.Errorf("invalid digit: %d", digit)
+    }
+
+    if !w.full {
+        w.buf = append(w.buf, digit)
+        w.full = len(w.buf) == cap(w.buf)
+    } else {
+        w.buf[w.start] = digit
+        w.start = (w.start + 1) % len(w.buf)
+    }
+
+    w.state = forwardState{}
+    for i := 0; i < len(w.buf); i++ {
+        w.state.push(w.buf[(w.start+i)%len(w.buf)])
+    }
+    return nil
+}
+
+// Full reports whether the window holds size digits.
+func (w *RollingWindow) Full() bool {
+    return w.full
+}
+
+// Digits returns the digits in the window, oldest first.
+func (w *RollingWindow) Digits() []int {
+    digits := make([]int, len(w.buf))
+    for i := range digits {
+        digits[i] = w.buf[(w.start+i)%len(w.buf)]
+    }
+    return digits
+}
+
+// Checksum returns the check digit for the digits in the window.
+func (w *RollingWindow) Checksum() int {
+    return w.state.checksum()
+}
+
+// Valid reports whether the window is full and its digits end in a correct
+// check digit.
+func (w *RollingWindow) Valid() bool {
+    return w.full && w.state.valid()
+}
+
+// ValidateChannel validates a number delivered as chunks of ASCII digit
+// bytes on ch. Chunks are folded in as they arrive, and the last byte
+// received before ch is closed is treated as the checksum digit.
+//
+// A chunk containing a non-digit byte makes ValidateChannel return an error
+// immediately, without draining the rest of ch. If ch is closed without
+// delivering any digits it returns ErrEmptyInput.
+func ValidateChannel(ch <-chan []byte) (bool, error) {
+    var state forwardState
+    count := 0
+    for chunk := range ch {
+        for _, b := range chunk {
+            digit := b - '0'
+            if digit > 9 {
+                return false, ErrNonDigit
+            }
+            state.push(int(digit))
+            count++
+        }
+    }
+
+    if count == 0 {
+        return false, ErrEmptyInput
+    }
+    return state.valid(), nil
+}
+
+// GenerateEcho copies the ASCII digits read from r to w and, once r reports
+// io.EOF, writes their checksum digit to w, so the output is the input with
+// its check digit appended. Digits are folded in chunk by chunk and never
+// buffered in full, so the number can be arbitrarily long.
+//
+// Any non-digit byte, including a trailing newline, stops the copy with an
+// error; the chunk holding it is not written. Empty input produces "0", as
+// AppendChecksumString("") does.
+func GenerateEcho(r io.Reader, w io.Writer) error {
+    var state forwardState
+    buf := make([]byte, 4096)
+    for {
+        n, readErr := r.Read(buf)
+        chunk := buf[:n]
+        for _, b := range chunk {
+            digit := b - '0'
+            if digit > 9 {
+                return ErrNonDigit
+            }
+            state.push(int(digit))
+        }
+        if _, err := w.Write(chunk); err != nil {
+            return err
+        }
+
+        if readErr == io.EOF {
+            break
+        }
+        if readErr != nil {
+            return readErr
+        }
+    }
+
+    _, err := w.Write([]byte{byte('0' + state.checksum())})
+    return err
+}
+
+// StreamResult is the outcome of generating one check digit in
+// GenerateStream.
+type StreamResult struct {
+    // Input is the string read from the input channel.
+    Input string
+    // Checksum is the check digit for Input, or -1 if Err is set.
+    Checksum int
+    // Err is set if Input could not be checksummed.
+    Err error
+}
+
+// streamJob carries one input to a GenerateStream worker along with the
+// channel its result goes to.
+type streamJob struct {
+    input  string
+    result chan<- StreamResult
+}
+
+// GenerateStream calculates check digits for the strings received on in
+// using workers goroutines and emits the results in input order, whatever
+// order the workers finish in. A workers value below 1 is treated as 1. The
+// returned channel is closed after in is closed and every result has been
+// delivered; the caller must drain it, or the goroutines will block. At
+// most workers results are buffered ahead of the slowest pending one.
+func GenerateStream(in <-chan string, workers int) <-chan StreamResult {
+    if workers < 1 {
+        workers = 1
+    }
+
+    out := make(chan StreamResult)
+    jobs := make(chan streamJob)
+    // pending holds one result channel per input, in input order.
+    pending := make(chan chan StreamResult, workers)
+
+    for i := 0; i < workers; i++ {
+        go func() {
+            for job := range jobs {
+                checksum, err := GenerateFromString(job.input)
+                job.result <- StreamResult{Input: job.input, Checksum: checksum, Err: err}
+            }
+        }()
+    }
+
+    go func() {
+        defer close(pending)
+        defer close(jobs)
+        for input := range in {
+            result := make(chan StreamResult, 1)
+            pending <- result
+            jobs <- streamJob{input: input, result: result}
+        }
+    }()
+
+    go func() {
+        defer close(out)
+        for result := range pending {
+            out <- <-result
+        }
+    }()
+
+    return out
+}
+
+// ValidateStreamFailFast reads whitespace-separated tokens from r, split with
+// bufio.ScanWords, and stops at the first token that is not a valid number.
+// It returns that token and its 0-based index among all tokens, or "" and -1
+// if every token passes. Tokens that are malformed count as failures; err is
+// only set when reading from r fails, including for tokens longer than
+// bufio.MaxScanTokenSize.
+func ValidateStreamFailFast(r io.Reader) (firstBadToken string, lineOrIndex int, err error) {
+    scanner := bufio.NewScanner(r)
+    scanner.Split(bufio.ScanWords)
+
+    index := 0
+    for scanner.Scan() {
+        token := scanner.Text()
+        valid, err := ValidateString(token)
+        if err != nil || !valid {
+            return token, index, nil
+        }
+        index++
+    }
+
+    if err := scanner.Err(); err != nil {
+        return "", -1, err
+    }
+    return "", -1, nil
+}
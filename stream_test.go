@@ -0,0 +1,598 @@
+// FilePath: stream_test.go
+
+package verhoeff
+
+import (
+    "errors"
+    "io"
+    "math/rand"
+    "strconv"
+    "strings"
+    "testing"
+    "testing/iotest"
+)
+
+// TestForwardStateMatchesCore checks the streaming recurrence against the
+// reversed slice implementation on pseudo-random inputs.
+func TestForwardStateMatchesCore(t *testing.T) {
+    rng := rand.New(rand.NewSource(7))
+
+    for i := 0; i < 500; i++ {
+        length := 1 + rng.Intn(40)
+        var number strings.Builder
+        var state forwardState
+        for j := 0; j < length; j++ {
+            digit := rng.Intn(10)
+            number.WriteByte(byte('0' + digit))
+            state.push(digit)
+        }
+
+        want, _ := GenerateFromString(number.String())
+        if got := state.checksum(); got != want {
+            t.Fatalf("checksum(%s) = %d, want %d", number.String(), got, want)
+        }
+
+        wantValid, _ := ValidateString(number.String())
+        if got := state.valid(); got != wantValid {
+            t.Fatalf("valid(%s) = %v, want %v", number.String(), got, wantValid)
+        }
+    }
+}
+
+func TestGenerateWithState(t *testing.T) {
+    tests := []struct {
+        name     string
+        segments []string
+        expected int
+        hasError bool
+    }{
+        {"Single segment", []string{"12345"}, 1, false},
+        {"Split segments", []string{"123", "45"}, 1, false},
+        {"One digit per segment", []string{"2", "3", "6"}, 3, false},
+        {"Empty segments", []string{"", "236", ""}, 3, false},
+        {"No segments", []string{}, 0, false},
+        {"Across the 8-digit cycle", []string{"1234567", "890123"}, 0, false},
+        {"Non-digit segment", []string{"123", "4a"}, -1, true},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            c := 0
+            var err error
+            for _, segment := range tt.segments {
+                c, err = GenerateWithState(c, segment)
+                if err != nil {
+                    break
+                }
+            }
+
+            if (err != nil) != tt.hasError {
+                t.Errorf("GenerateWithState() error = %v, wantErr %v",
+                    err, tt.hasError)
+                return
+            }
+
+            if tt.hasError {
+                return
+            }
+
+            if got := Finalize(c); got != tt.expected {
+                t.Errorf("Finalize() = %d, want %d", got, tt.expected)
+            }
+        })
+    }
+
+    t.Run("Invalid state", func(t *testing.T) {
+        for _, c := range []int{-1, 100000000} {
+            if _, err := GenerateWithState(c, "1"); err == nil {
+                t.Errorf("GenerateWithState(%d) expected error", c)
+            }
+            if got := Finalize(c); got != -1 {
+                t.Errorf("Finalize(%d) = %d, want -1", c, got)
+            }
+        }
+    })
+
+    t.Run("Random splits match core", func(t *testing.T) {
+        rng := rand.New(rand.NewSource(11))
+        for i := 0; i < 200; i++ {
+            number := make([]byte, 1+rng.Intn(30))
+            for j := range number {
+                number[j] = byte('0' + rng.Intn(10))
+            }
+            split := rng.Intn(len(number) + 1)
+
+            c, _ := GenerateWithState(0, string(number[:split]))
+            c, _ = GenerateWithState(c, string(number[split:]))
+
+            want, _ := GenerateFromString(string(number))
+            if got := Finalize(c); got != want {
+                t.Fatalf("Finalize(%s | %s) = %d, want %d",
+                    number[:split], number[split:], got, want)
+            }
+        }
+    })
+}
+
+func TestPrefixChecksums(t *testing.T) {
+    tests := []struct {
+        name     string
+        input    string
+        expected []int
+        hasError bool
+    }{
+        {"Nine digits", "123456789", []int{5, 1, 3, 0, 1, 8, 9, 4, 0}, false},
+        {"Single digit", "0", []int{4}, false},
+        {"Empty string", "", []int{}, false},
+        {"Non-digit", "12a", nil, true},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            got, err := PrefixChecksums(tt.input)
+
+            if (err != nil) != tt.hasError {
+                t.Errorf("PrefixChecksums() error = %v, wantErr %v",
+                    err, tt.hasError)
+                return
+            }
+
+            if len(got) != len(tt.expected) {
+                t.Errorf("PrefixChecksums() = %v, want %v", got, tt.expected)
+                return
+            }
+
+            for i := range got {
+                if got[i] != tt.expected[i] {
+                    t.Errorf("PrefixChecksums() = %v, want %v",
+                        got, tt.expected)
+                    return
+                }
+            }
+        })
+    }
+}
+
+func TestChecksummer(t *testing.T) {
+    tests := []struct {
+        name     string
+        input    string
+        expected int
+        hasError bool
+    }{
+        {"Five digits", "12345", 1, false},
+        {"Nine digits", "123456789", 0, false},
+        {"Long number", strings.Repeat("1234567890", 1000), 0, false},
+        {"Empty input", "", 0, false},
+        {"Non-digit", "12a45", -1, true},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            c := NewChecksummer()
+            _, err := io.Copy(c, iotest.HalfReader(strings.NewReader(tt.input)))
+
+            if (err != nil) != tt.hasError {
+                t.Errorf("io.Copy() error = %v, wantErr %v", err, tt.hasError)
+                return
+            }
+            if tt.hasError {
+                return
+            }
+
+            if got := c.Sum(); got != tt.expected {
+                t.Errorf("Sum() = %v, want %v", got, tt.expected)
+            }
+        })
+    }
+
+    t.Run("Error mid-chunk", func(t *testing.T) {
+        c := NewChecksummer()
+        n, err := c.Write([]byte("12a4"))
+        if n != 2 || err == nil {
+            t.Errorf("Write() = (%d, %v), want (2, error)", n, err)
+        }
+        if got := c.Sum(); got != 1 {
+            t.Errorf("Sum() after error = %v, want 1", got)
+        }
+    })
+
+    t.Run("Reset", func(t *testing.T) {
+        c := NewChecksummer()
+        _, _ = c.Write([]byte("999"))
+        c.Reset()
+        _, _ = c.Write([]byte("23"))
+        _, _ = c.Write([]byte("6"))
+        if got := c.Sum(); got != 3 {
+            t.Errorf("Sum() after Reset = %v, want 3", got)
+        }
+    })
+}
+
+func TestPrefixComputer(t *testing.T) {
+    const prefix = "12345678901234567890"
+    pc, err := NewPrefixComputer(prefix)
+    if err != nil {
+        t.Fatalf("NewPrefixComputer() error = %v", err)
+    }
+
+    tests := []struct {
+        name     string
+        suffix   string
+        expected int
+        hasError bool
+    }{
+        {"Serial", "0001", 4, false},
+        {"Short serial", "42", 1, false},
+        {"Empty suffix", "", 1, false},
+        {"Long serial", "999999", 1, false},
+        {"Non-digit", "12a", -1, true},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            got, err := pc.ForSuffix(tt.suffix)
+
+            if (err != nil) != tt.hasError {
+                t.Errorf("ForSuffix() error = %v, wantErr %v", err, tt.hasError)
+                return
+            }
+
+            if got != tt.expected {
+                t.Errorf("ForSuffix() = %v, want %v", got, tt.expected)
+            }
+        })
+    }
+
+    t.Run("Matches full recomputation", func(t *testing.T) {
+        rng := rand.New(rand.NewSource(19))
+        randomDigits := func() string {
+            var b strings.Builder
+            for n := rng.Intn(30); n > 0; n-- {
+                b.WriteByte(byte('0' + rng.Intn(10)))
+            }
+            return b.String()
+        }
+        for i := 0; i < 200; i++ {
+            prefix, suffix := randomDigits(), randomDigits()
+            pc, _ := NewPrefixComputer(prefix)
+            got, _ := pc.ForSuffix(suffix)
+            want, _ := GenerateFromString(prefix + suffix)
+            if got != want {
+                t.Fatalf("ForSuffix(%q) after %q = %d, want %d", suffix, prefix, got, want)
+            }
+        }
+    })
+
+    if _, err := NewPrefixComputer("12a"); err == nil {
+        t.Errorf("NewPrefixComputer(\"12a\") expected error")
+    }
+}
+
+func TestValidateWithCallback(t *testing.T) {
+    tests := []struct {
+        name     string
+        input    string
+        expected bool
+        hasError bool
+    }{
+        {"Valid number", "123451", true, false},
+        {"Invalid number", "123450", false, false},
+        {"Empty string", "", false, true},
+        {"Non-digit", "12a451", false, true},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            var reported []int
+            got, err := ValidateWithCallback(tt.input, func(processed int) bool {
+                reported = append(reported, processed)
+                return true
+            })
+
+            if (err != nil) != tt.hasError {
+                t.Errorf("ValidateWithCallback() error = %v, wantErr %v",
+                    err, tt.hasError)
+                return
+            }
+
+            if got != tt.expected {
+                t.Errorf("ValidateWithCallback() = %v, want %v", got, tt.expected)
+            }
+
+            if !tt.hasError && (len(reported) != 1 || reported[0] != len(tt.input)) {
+                t.Errorf("callback saw %v, want [%d]", reported, len(tt.input))
+            }
+        })
+    }
+
+    long, _ := AppendChecksumString(strings.Repeat("1234567890", 1000))
+
+    t.Run("Cadence", func(t *testing.T) {
+        var reported []int
+        got, err := ValidateWithCallback(long, func(processed int) bool {
+            reported = append(reported, processed)
+            return true
+        })
+        if err != nil || !got {
+            t.Fatalf("ValidateWithCallback() = %v, %v, want true", got, err)
+        }
+
+        expected := []int{4096, 8192, len(long)}
+        if len(reported) != len(expected) {
+            t.Fatalf("callback saw %v, want %v", reported, expected)
+        }
+        for i := range expected {
+            if reported[i] != expected[i] {
+                t.Fatalf("callback saw %v, want %v", reported, expected)
+            }
+        }
+    })
+
+    t.Run("Cancel", func(t *testing.T) {
+        calls := 0
+        _, err := ValidateWithCallback(long, func(int) bool {
+            calls++
+            return false
+        })
+        if !errors.Is(err, ErrCancelled) || calls != 1 {
+            t.Errorf("ValidateWithCallback() error = %v after %d calls, want ErrCancelled after 1",
+                err, calls)
+        }
+    })
+
+    t.Run("Nil callback", func(t *testing.T) {
+        if got, err := ValidateWithCallback(long, nil); err != nil || !got {
+            t.Errorf("ValidateWithCallback() = %v, %v, want true", got, err)
+        }
+    })
+}
+
+func TestValidateChannel(t *testing.T) {
+    tests := []struct {
+        name     string
+        chunks   []string
+        expected bool
+        hasError bool
+    }{
+        {"Single chunk", []string{"123451"}, true, false},
+        {"Split chunks", []string{"12", "34", "51"}, true, false},
+        {"Check digit alone", []string{"12345", "1"}, true, false},
+        {"Empty chunks in between", []string{"236", "", "3"}, true, false},
+        {"Invalid number", []string{"1234", "50"}, false, false},
+        {"Non-digit chunk", []string{"123", "4a"}, false, true},
+        {"No chunks", []string{}, false, true},
+        {"Only empty chunks", []string{"", ""}, false, true},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            ch := make(chan []byte, len(tt.chunks))
+            for _, chunk := range tt.chunks {
+                ch <- []byte(chunk)
+            }
+            close(ch)
+
+            got, err := ValidateChannel(ch)
+
+            if (err != nil) != tt.hasError {
+                t.Errorf("ValidateChannel() error = %v, wantErr %v",
+                    err, tt.hasError)
+                return
+            }
+
+            if got != tt.expected {
+                t.Errorf("ValidateChannel() = %v, want %v", got, tt.expected)
+            }
+        })
+    }
+
+    t.Run("Empty reports ErrEmptyInput", func(t *testing.T) {
+        ch := make(chan []byte)
+        close(ch)
+        if _, err := ValidateChannel(ch); !errors.Is(err, ErrEmptyInput) {
+            t.Errorf("ValidateChannel() error = %v, want ErrEmptyInput", err)
+        }
+    })
+}
+
+func TestValidateStreamFailFast(t *testing.T) {
+    tests := []struct {
+        name          string
+        input         string
+        expectedToken string
+        expectedIndex int
+    }{
+        {"All valid", "2363 123451\n1428570\n", "", -1},
+        {"Invalid token", "2363\n2364 123451", "2364", 1},
+        {"Malformed token", "2363 12a4 2364", "12a4", 1},
+        {"Stops at first failure", "2364 2365", "2364", 0},
+        {"Empty input", "", "", -1},
+        {"Only whitespace", " \n\t ", "", -1},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            token, index, err := ValidateStreamFailFast(strings.NewReader(tt.input))
+            if err != nil {
+                t.Fatalf("ValidateStreamFailFast() error = %v", err)
+            }
+
+            if token != tt.expectedToken || index != tt.expectedIndex {
+                t.Errorf("ValidateStreamFailFast() = (%q, %d), want (%q, %d)",
+                    token, index, tt.expectedToken, tt.expectedIndex)
+            }
+        })
+    }
+}
+
+func TestGenerateEcho(t *testing.T) {
+    tests := []struct {
+        name     string
+        input    string
+        expected string
+        hasError bool
+    }{
+        {"Short number", "12345", "123451", false},
+        {"Empty input", "", "0", false},
+        {"Trailing newline", "12345\n", "", true},
+        {"Non-digit", "12a45", "", true},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            var out strings.Builder
+            err := GenerateEcho(strings.NewReader(tt.input), &out)
+
+            if (err != nil) != tt.hasError {
+                t.Errorf("GenerateEcho() error = %v, wantErr %v",
+                    err, tt.hasError)
+                return
+            }
+
+            if out.String() != tt.expected {
+                t.Errorf("GenerateEcho() wrote %q, want %q",
+                    out.String(), tt.expected)
+            }
+        })
+    }
+
+    number := strings.Repeat("1234567890", 1000)
+    want, _ := AppendChecksumString(number)
+
+    t.Run("Longer than one chunk", func(t *testing.T) {
+        var out strings.Builder
+        if err := GenerateEcho(strings.NewReader(number), &out); err != nil {
+            t.Fatalf("GenerateEcho() error = %v", err)
+        }
+        if out.String() != want {
+            t.Errorf("GenerateEcho() output does not match AppendChecksumString")
+        }
+    })
+
+    t.Run("One byte per read", func(t *testing.T) {
+        var out strings.Builder
+        r := iotest.OneByteReader(strings.NewReader(number))
+        if err := GenerateEcho(r, &out); err != nil {
+            t.Fatalf("GenerateEcho() error = %v", err)
+        }
+        if out.String() != want {
+            t.Errorf("GenerateEcho() output does not match AppendChecksumString")
+        }
+    })
+}
+
+func TestGenerateStream(t *testing.T) {
+    inputs := make([]string, 1000)
+    for i := range inputs {
+        inputs[i] = strconv.Itoa(i * 7919)
+    }
+    inputs[10] = "12a45"
+    inputs[500] = ""
+
+    for _, workers := range []int{0, 1, 8, 64} {
+        t.Run("Workers "+strconv.Itoa(workers), func(t *testing.T) {
+            in := make(chan string)
+            go func() {
+                defer close(in)
+                for _, input := range inputs {
+                    in <- input
+                }
+            }()
+
+            i := 0
+            for result := range GenerateStream(in, workers) {
+                if i >= len(inputs) {
+                    t.Fatalf("GenerateStream() emitted more than %d results", len(inputs))
+                }
+                if result.Input != inputs[i] {
+                    t.Fatalf("result %d is for %q, want %q", i, result.Input, inputs[i])
+                }
+
+                want, wantErr := GenerateFromString(inputs[i])
+                if result.Checksum != want || (result.Err != nil) != (wantErr != nil) {
+                    t.Errorf("result %d = %+v, want checksum %d, error %v",
+                        i, result, want, wantErr)
+                }
+                i++
+            }
+
+            if i != len(inputs) {
+                t.Errorf("GenerateStream() emitted %d results, want %d", i, len(inputs))
+            }
+        })
+    }
+
+    t.Run("Closed input", func(t *testing.T) {
+        in := make(chan string)
+        close(in)
+        for result := range GenerateStream(in, 4) {
+            t.Errorf("GenerateStream() emitted %+v for no input", result)
+        }
+    })
+}
+
+func TestRollingWindow(t *testing.T) {
+    window, err := NewRollingWindow(6)
+    if err != nil {
+        t.Fatalf("NewRollingWindow() error = %v", err)
+    }
+
+    stream := "9912345100"
+    var validAt []int
+    for i := 0; i < len(stream); i++ {
+        if err := window.Push(int(stream[i] - '0')); err != nil {
+            t.Fatalf("Push() error = %v", err)
+        }
+
+        start := i + 1 - 6
+        if start < 0 {
+            start = 0
+        }
+        current := stream[start : i+1]
+        if got := digitsToString(window.Digits()); got != current {
+            t.Fatalf("Digits() = %s, want %s", got, current)
+        }
+        want, _ := GenerateFromString(current)
+        if got := window.Checksum(); got != want {
+            t.Errorf("Checksum() over %s = %d, want %d", current, got, want)
+        }
+        if window.Full() != (i >= 5) {
+            t.Errorf("Full() = %v after %d digits", window.Full(), i+1)
+        }
+        if window.Valid() {
+            validAt = append(validAt, i)
+        }
+    }
+
+    // "123451" ends at index 7 of the stream.
+    if len(validAt) != 1 || validAt[0] != 7 {
+        t.Errorf("Valid() was true at %v, want [7]", validAt)
+    }
+
+    if err := window.Push(10); err == nil {
+        t.Errorf("Push(10) expected error")
+    }
+    if _, err := NewRollingWindow(0); err == nil {
+        t.Errorf("NewRollingWindow(0) expected error")
+    }
+}
+
+// BenchmarkPrefixComputer generates check digits for 6-digit serials behind
+// a 20-digit prefix folded in once.
+func BenchmarkPrefixComputer(b *testing.B) {
+    pc, _ := NewPrefixComputer("12345678901234567890")
+
+    b.ResetTimer()
+    for i := 0; i < b.N; i++ {
+        _, _ = pc.ForSuffix("123456")
+    }
+}
+
+// BenchmarkPrefixRecompute generates the same check digits from the full
+// number each time, for comparison.
+func BenchmarkPrefixRecompute(b *testing.B) {
+    for i := 0; i < b.N; i++ {
+        _, _ = GenerateFromString("12345678901234567890" + "123456")
+    }
+}
@@ -366,6 +366,55 @@ func TestExhaustiveSmallNumbers(t *testing.T) {
     }
 }
 
+// TestExhaustiveParsePaths checks that the ASCII fast paths in
+// stringToDigits and validateString and the byte functions agree with the
+// per-rune parser and the string functions for every number of up to 5
+// digits.
+func TestExhaustiveParsePaths(t *testing.T) {
+    if testing.Short() {
+        t.Skip("Skipping exhaustive test in short mode")
+    }
+
+    for width := 1; width <= 5; width++ {
+        limit := 1
+        for i := 0; i < width; i++ {
+            limit *= 10
+        }
+        for i := 0; i < limit; i++ {
+            number := fmt.Sprintf("%0*d", width, i)
+
+            fast, _ := stringToDigits(number)
+            slow, _ := stringToDigitsUnicode(number)
+            if fmt.Sprint(fast) != fmt.Sprint(slow) {
+                t.Fatalf("stringToDigits(%s) = %v, per-rune parser gives %v",
+                    number, fast, slow)
+            }
+
+            if got, _ := ValidateString(number); got != validateChecksum(slow) {
+                t.Fatalf("ValidateString(%s) = %v, per-rune parser gives %v",
+                    number, got, !got)
+            }
+
+            wantDigit, _ := GenerateFromString(number)
+            gotDigit, _ := GenerateBytes([]byte(number))
+            wantValid, _ := ValidateString(number)
+            gotValid, _ := ValidateBytes([]byte(number))
+            if gotDigit != wantDigit || gotValid != wantValid {
+                t.Fatalf("byte path for %s = (%d, %v), string path = (%d, %v)",
+                    number, gotDigit, gotValid, wantDigit, wantValid)
+            }
+        }
+    }
+
+    // Non-ASCII digits still take the per-rune path.
+    fast, err := stringToDigits("12\u0663")
+    slow, _ := stringToDigitsUnicode("12\u0663")
+    if err != nil || fmt.Sprint(fast) != fmt.Sprint(slow) {
+        t.Errorf("stringToDigits() = %v, %v for non-ASCII digit, want %v",
+            fast, err, slow)
+    }
+}
+
 // TestPerformanceConsistency tests that performance is consistent
 func TestPerformanceConsistency(t *testing.T) {
     testInputs := []string{
@@ -431,6 +480,69 @@ func BenchmarkValidateLongNumber(b *testing.B) {
     }
 }
 
+// BenchmarkParseDigitsASCII benchmarks the byte-wise fast path of
+// stringToDigits on a 10k-digit input. Both parsers allocate the one digit
+// slice they return, so this only compares speed; see
+// BenchmarkValidateStringASCII for the allocation-free path.
+func BenchmarkParseDigitsASCII(b *testing.B) {
+    longNumber := strings.Repeat("1234567890", 1000)
+
+    b.ReportAllocs()
+    b.ResetTimer()
+    for i := 0; i < b.N; i++ {
+        _, _ = stringToDigits(longNumber)
+    }
+}
+
+// BenchmarkParseDigitsPerRune benchmarks the per-rune parser with
+// unicode.IsDigit and strconv on the same input, for comparison.
+func BenchmarkParseDigitsPerRune(b *testing.B) {
+    longNumber := strings.Repeat("1234567890", 1000)
+
+    b.ReportAllocs()
+    b.ResetTimer()
+    for i := 0; i < b.N; i++ {
+        _, _ = stringToDigitsUnicode(longNumber)
+    }
+}
+
+// BenchmarkValidateStringASCII benchmarks ValidateString on a 10k-digit
+// ASCII input, which is checked without building a digit slice.
+func BenchmarkValidateStringASCII(b *testing.B) {
+    longNumber := strings.Repeat("1234567890", 1000)
+
+    b.ReportAllocs()
+    b.ResetTimer()
+    for i := 0; i < b.N; i++ {
+        _, _ = ValidateString(longNumber)
+    }
+}
+
+// BenchmarkValidateStringPerRune benchmarks validation through the per-rune
+// parser on the same input, for comparison.
+func BenchmarkValidateStringPerRune(b *testing.B) {
+    longNumber := strings.Repeat("1234567890", 1000)
+
+    b.ReportAllocs()
+    b.ResetTimer()
+    for i := 0; i < b.N; i++ {
+        digits, _ := stringToDigitsUnicode(longNumber)
+        _ = validateChecksum(digits)
+    }
+}
+
+// BenchmarkValidateBytesLong benchmarks the allocation-free byte path on a
+// 10k-digit input.
+func BenchmarkValidateBytesLong(b *testing.B) {
+    longNumber := []byte(strings.Repeat("1234567890", 1000))
+
+    b.ReportAllocs()
+    b.ResetTimer()
+    for i := 0; i < b.N; i++ {
+        _, _ = ValidateBytes(longNumber)
+    }
+}
+
 // BenchmarkConcurrentGenerate benchmarks concurrent generation
 func BenchmarkConcurrentGenerate(b *testing.B) {
     numbers := []string{
@@ -447,4 +559,124 @@ func BenchmarkConcurrentGenerate(b *testing.B) {
             i++
         }
     })
-}
\ No newline at end of file
+}
+
+// calculateChecksumNested is calculateChecksum with separate d and p lookups
+// instead of the fused dp table, kept as a baseline for the benchmarks below.
+// It walks the digits right to left like calculateChecksum, so the two differ
+// only in the table fusion.
+func calculateChecksumNested(digits []int) int {
+    c := 0
+    for i := len(digits) - 1; i >= 0; i-- {
+        c = d[c][p[(len(digits)-i)%8][digits[i]]]
+    }
+    return inv[c]
+}
+
+// TestFusedTable checks every dp entry against the d and p tables it is
+// built from, and the fused loop against both baselines.
+func TestFusedTable(t *testing.T) {
+    for pos := 0; pos < 8; pos++ {
+        for c := 0; c < 10; c++ {
+            for digit := 0; digit < 10; digit++ {
+                if got, want := int(dp[pos*dpRowSize+c*10+digit]), d[c][p[pos][digit]]; got != want {
+                    t.Fatalf("dp[%d][%d][%d] = %d, want %d", pos, c, digit, got, want)
+                }
+            }
+        }
+    }
+
+    rng := rand.New(rand.NewSource(23))
+    for n := 0; n < 500; n++ {
+        digits := make([]int, rng.Intn(50))
+        for i := range digits {
+            digits[i] = rng.Intn(10)
+        }
+        if got, want := calculateChecksum(digits), calculateChecksumNested(digits); got != want {
+            t.Fatalf("calculateChecksum(%v) = %d, want %d", digits, got, want)
+        }
+        if got, want := calculateChecksum(digits), calculateChecksumCopy(digits); got != want {
+            t.Fatalf("calculateChecksum(%v) = %d, want copying baseline %d", digits, got, want)
+        }
+    }
+}
+
+// BenchmarkChecksumNested benchmarks the nested d[c][p[(i+1)%8][digit]]
+// lookup on a 10k-digit input.
+func BenchmarkChecksumNested(b *testing.B) {
+    digits, _ := stringToDigits(strings.Repeat("1234567890", 1000))
+
+    b.ResetTimer()
+    for i := 0; i < b.N; i++ {
+        _ = calculateChecksumNested(digits)
+    }
+}
+
+// BenchmarkChecksumFused benchmarks calculateChecksum, which uses the fused
+// dp table, on the same input.
+func BenchmarkChecksumFused(b *testing.B) {
+    digits, _ := stringToDigits(strings.Repeat("1234567890", 1000))
+
+    b.ResetTimer()
+    for i := 0; i < b.N; i++ {
+        _ = calculateChecksum(digits)
+    }
+}
+
+// TestChecksumDoesNotAllocate checks that the core loops walk the digits in
+// place rather than reversing a copy.
+func TestChecksumDoesNotAllocate(t *testing.T) {
+    digits, _ := stringToDigits(strings.Repeat("1234567890", 100))
+
+    if allocs := testing.AllocsPerRun(100, func() { _ = calculateChecksum(digits) }); allocs != 0 {
+        t.Errorf("calculateChecksum() allocates %v times per call, want 0", allocs)
+    }
+    if allocs := testing.AllocsPerRun(100, func() { _ = validateChecksum(digits) }); allocs != 0 {
+        t.Errorf("validateChecksum() allocates %v times per call, want 0", allocs)
+    }
+}
+
+// calculateChecksumCopy is calculateChecksum as it was before it walked the
+// digits in place: it reverses a copy of the slice first, kept as the
+// allocating baseline for BenchmarkChecksumAllocs.
+func calculateChecksumCopy(digits []int) int {
+    reversed := make([]int, len(digits))
+    copy(reversed, digits)
+    reverseDigits(reversed)
+
+    c := 0
+    row := dpRowSize // position 1
+    for _, digit := range reversed {
+        c = int(dp[row+c*10+digit])
+        row += dpRowSize
+        if row == len(dp) {
+            row = 0
+        }
+    }
+    return inv[c]
+}
+
+// BenchmarkChecksumAllocs reports allocations for the generate and validate
+// loops on a 10k-digit input, next to the copying baseline.
+func BenchmarkChecksumAllocs(b *testing.B) {
+    digits, _ := stringToDigits(strings.Repeat("1234567890", 1000))
+
+    b.Run("copy", func(b *testing.B) {
+        b.ReportAllocs()
+        for i := 0; i < b.N; i++ {
+            _ = calculateChecksumCopy(digits)
+        }
+    })
+    b.Run("generate", func(b *testing.B) {
+        b.ReportAllocs()
+        for i := 0; i < b.N; i++ {
+            _ = calculateChecksum(digits)
+        }
+    })
+    b.Run("validate", func(b *testing.B) {
+        b.ReportAllocs()
+        for i := 0; i < b.N; i++ {
+            _ = validateChecksum(digits)
+        }
+    })
+}
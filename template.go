@@ -0,0 +1,30 @@
+// FilePath: template.go
+
+package verhoeff
+
+import (
+    "text/template"
+)
+
+// FuncMap returns template functions for rendering IDs:
+//
+//   - verhoeffValid reports whether a number is valid, or false on error.
+//   - verhoeffAppend appends the checksum digit, or returns "" on error.
+//
+// Templates cannot easily handle errors, so both functions degrade to their
+// zero value instead. The map also works with html/template.
+func FuncMap() template.FuncMap {
+    return template.FuncMap{
+        "verhoeffValid": func(s string) bool {
+            valid, err := ValidateString(s)
+            return err == nil && valid
+        },
+        "verhoeffAppend": func(s string) string {
+            full, err := AppendChecksumString(s)
+            if err != nil {
+                return ""
+            }
+            return full
+        },
+    }
+}
@@ -0,0 +1,44 @@
+// FilePath: template_test.go
+
+package verhoeff
+
+import (
+    "strings"
+    "testing"
+    "text/template"
+)
+
+func TestFuncMap(t *testing.T) {
+    tests := []struct {
+        name     string
+        tmpl     string
+        input    string
+        expected string
+    }{
+        {"Valid ID", "{{ .ID | verhoeffValid }}", "2363", "true"},
+        {"Invalid ID", "{{ .ID | verhoeffValid }}", "2364", "false"},
+        {"Malformed ID", "{{ .ID | verhoeffValid }}", "23a3", "false"},
+        {"Append", "{{ .ID | verhoeffAppend }}", "236", "2363"},
+        {"Append malformed", "[{{ .ID | verhoeffAppend }}]", "2a6", "[]"},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            tmpl, err := template.New("id").Funcs(FuncMap()).Parse(tt.tmpl)
+            if err != nil {
+                t.Fatalf("Parse() error = %v", err)
+            }
+
+            var out strings.Builder
+            data := struct{ ID string }{tt.input}
+            if err := tmpl.Execute(&out, data); err != nil {
+                t.Fatalf("Execute() error = %v", err)
+            }
+
+            if out.String() != tt.expected {
+                t.Errorf("template output = %q, want %q",
+                    out.String(), tt.expected)
+            }
+        })
+    }
+}
@@ -0,0 +1,88 @@
+// FilePath: token.go
+
+package verhoeff
+
+import (
+    "errors"
+    "fmt"
+    "unicode"
+)
+
+// Position is a location in a source text. Line and Column start at 1, and
+// Column counts characters, not bytes, as text/scanner does.
+type Position struct {
+    Line   int
+    Column int
+}
+
+// TokenError reports a token that failed validation in ValidateTokens.
+type TokenError struct {
+    // Token is the token as returned by the iterator.
+    Token string
+    // Pos is where the failure is: the first non-digit character of a
+    // malformed token, or the start of a token with a wrong check digit.
+    Pos Position
+    // Err is ErrChecksumMismatch for a wrong check digit, or describes why
+    // the token could not be validated.
+    Err error
+}
+
+func (e *TokenError) Error() string {
+    return fmt.Sprintf("%d:%d: %q: %v", e.Pos.Line, e.Pos.Column, e.Token, e.Err)
+}
+
+func (e *TokenError) Unwrap() error {
+    return e.Err
+}
+
+// ValidateTokens validates every token produced by next, a pull-based
+// iterator returning ok false once it is exhausted, and returns one
+// TokenError per failing token in the order they were produced. The
+// iterator decides which tokens are IDs, so ValidateTokens fits any
+// tokenizer; pos is where the token starts, and for a text/scanner.Scanner
+// is simply its Position.Line and Position.Column after Scan.
+//
+// Failures in the tokens do not stop the run; err is only set if next is
+// nil.
+func ValidateTokens(next func() (tok string, pos Position, ok bool)) ([]TokenError, error) {
+    if next == nil {
+        return nil, errors.New("nil token iterator")
+    }
+
+    failures := []TokenError{}
+    for {
+        tok, pos, ok := next()
+        if !ok {
+            return failures, nil
+        }
+        if err := validateToken(tok, &pos); err != nil {
+            failures = append(failures, TokenError{Token: tok, Pos: pos, Err: err})
+        }
+    }
+}
+
+// validateToken validates a single token starting at pos, moving pos to
+// the offending character if the token is malformed.
+func validateToken(tok string, pos *Position) error {
+    if tok == "" {
+        return ErrEmptyInput
+    }
+
+    column := 0
+    for offset, r := range tok {
+        if !unicode.IsDigit(r) {
+            pos.Column += column
+            return &InvalidCharError{Char: r, Offset: offset}
+        }
+        column++
+    }
+
+    valid, err := ValidateString(tok)
+    if err != nil {
+        return err
+    }
+    if !valid {
+        return ErrChecksumMismatch
+    }
+    return nil
+}
@@ -0,0 +1,99 @@
+// FilePath: token_test.go
+
+package verhoeff
+
+import (
+    "errors"
+    "reflect"
+    "strings"
+    "testing"
+    "text/scanner"
+)
+
+// sliceTokens returns an iterator over toks for ValidateTokens.
+func sliceTokens(toks []string, pos []Position) func() (string, Position, bool) {
+    i := 0
+    return func() (string, Position, bool) {
+        if i == len(toks) {
+            return "", Position{}, false
+        }
+        i++
+        return toks[i-1], pos[i-1], true
+    }
+}
+
+func TestValidateTokens(t *testing.T) {
+    tests := []struct {
+        name          string
+        tokens        []string
+        positions     []Position
+        expectedPos   []Position
+        expectedErr   error
+    }{
+        {"All valid", []string{"2363", "123451"},
+            []Position{{1, 1}, {2, 5}}, []Position{}, nil},
+        {"Wrong checksum", []string{"2363", "2364"},
+            []Position{{1, 1}, {2, 5}}, []Position{{2, 5}}, ErrChecksumMismatch},
+        {"Non-digit reports its column", []string{"12a451"},
+            []Position{{3, 10}}, []Position{{3, 12}}, ErrNonDigit},
+        {"Column counts characters", []string{"٢٣x3"},
+            []Position{{1, 1}}, []Position{{1, 3}}, ErrNonDigit},
+        {"Empty token", []string{""},
+            []Position{{4, 2}}, []Position{{4, 2}}, ErrEmptyInput},
+        {"No tokens", nil, nil, []Position{}, nil},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            failures, err := ValidateTokens(sliceTokens(tt.tokens, tt.positions))
+            if err != nil {
+                t.Fatalf("ValidateTokens() error = %v", err)
+            }
+
+            positions := []Position{}
+            for _, f := range failures {
+                positions = append(positions, f.Pos)
+            }
+            if !reflect.DeepEqual(positions, tt.expectedPos) {
+                t.Fatalf("ValidateTokens() positions = %v, want %v", positions, tt.expectedPos)
+            }
+            if tt.expectedErr != nil && !errors.Is(&failures[0], tt.expectedErr) {
+                t.Errorf("ValidateTokens() failure = %v, want %v", &failures[0], tt.expectedErr)
+            }
+        })
+    }
+
+    t.Run("Nil iterator", func(t *testing.T) {
+        if _, err := ValidateTokens(nil); err == nil {
+            t.Errorf("ValidateTokens(nil) error = nil, want error")
+        }
+    })
+}
+
+func TestValidateTokensScanner(t *testing.T) {
+    src := "user = 2363\n  order = 2364 # bad\nref = 123451\n"
+    var s scanner.Scanner
+    s.Init(strings.NewReader(src))
+    s.Mode = scanner.ScanIdents | scanner.ScanInts
+    next := func() (string, Position, bool) {
+        for tok := s.Scan(); tok != scanner.EOF; tok = s.Scan() {
+            if tok == scanner.Int {
+                return s.TokenText(), Position{s.Position.Line, s.Position.Column}, true
+            }
+        }
+        return "", Position{}, false
+    }
+
+    failures, err := ValidateTokens(next)
+    if err != nil {
+        t.Fatalf("ValidateTokens() error = %v", err)
+    }
+    want := []TokenError{{Token: "2364", Pos: Position{2, 11}, Err: ErrChecksumMismatch}}
+    if !reflect.DeepEqual(failures, want) {
+        t.Fatalf("ValidateTokens() = %v, want %v", failures, want)
+    }
+    wantMsg := `2:11: "2364": checksum validation failed`
+    if got := failures[0].Error(); got != wantMsg {
+        t.Errorf("TokenError.Error() = %q, want %q", got, wantMsg)
+    }
+}
@@ -8,9 +8,13 @@
 package verhoeff
 
 import (
+    "crypto/subtle"
     "errors"
     "fmt"
+    "math"
+    "math/big"
     "strconv"
+    "time"
     "unicode"
 )
 
@@ -46,17 +50,83 @@ var (
     inv = []int{0, 4, 3, 2, 1, 5, 6, 7, 8, 9}
 )
 
+// maxSafeFloat is 2^53, the smallest integer magnitude at which float64
+// stops being exact: 2^53+1 rounds to 2^53, so a float64 of 2^53 may stand
+// for either. The largest safe magnitude is 2^53-1.
+const maxSafeFloat = 1 << 53
+
+// ErrEmptyInput is returned when a number to validate has no digits.
+var ErrEmptyInput = errors.New("empty input")
+
+// ErrNonDigit is returned when input that should hold only digits contains
+// some other character. Errors from string input are *InvalidCharError
+// values that match ErrNonDigit with errors.Is.
+var ErrNonDigit = errors.New("input contains non-digit characters")
+
+// ErrInvalidDigit is returned when a slice of digits holds a value outside
+// 0-9.
+var ErrInvalidDigit = errors.New("input contains invalid digit")
+
+// ErrInvalidLength is returned when a number of fixed length, such as an
+// Aadhaar number, has the wrong number of characters.
+var ErrInvalidLength = errors.New("invalid length")
+
+// InvalidCharError reports the first character of a string that is not a
+// digit. It matches ErrNonDigit with errors.Is.
+type InvalidCharError struct {
+    // Char is the offending character.
+    Char rune
+    // Offset is the byte offset of Char in the input.
+    Offset int
+}
+
+func (e *InvalidCharError) Error() string {
+    return fmt.Sprintf("%v: %q at offset %d", ErrNonDigit, e.Char, e.Offset)
+}
+
+func (e *InvalidCharError) Unwrap() error {
+    return ErrNonDigit
+}
+
+// ObserveLatency, when set, is called with the duration of every call to
+// Generate, Validate, GenerateFromString and ValidateString, for example to
+// feed a latency histogram. Calls the dispatchers make internally are not
+// reported twice. Set it once at startup, before any checksums are
+// computed; changing it concurrently with those calls is a data race. When
+// nil, the only cost is a nil check.
+var ObserveLatency func(d time.Duration)
+
 // stringToDigits converts a string to a slice of digits.
-// It returns an error if the string contains non-digit characters.
+// It returns an *InvalidCharError if the string contains non-digit
+// characters.
+//
+// ASCII digits are parsed with a subtraction and a single unsigned
+// comparison per byte, since b-'0' wraps around for bytes below '0'. Any
+// other byte hands the whole string to stringToDigitsUnicode, so results
+// are unchanged for non-ASCII input.
 func stringToDigits(s string) ([]int, error) {
+    digits := make([]int, len(s))
+    for i := 0; i < len(s); i++ {
+        digit := s[i] - '0'
+        if digit > 9 {
+            return stringToDigitsUnicode(s)
+        }
+        digits[i] = int(digit)
+    }
+    return digits, nil
+}
+
+// stringToDigitsUnicode is the per-rune parser behind stringToDigits,
+// accepting any character unicode.IsDigit accepts.
+func stringToDigitsUnicode(s string) ([]int, error) {
     if s == "" {
         return []int{}, nil
     }
     
     digits := make([]int, 0, len(s))
-    for _, char := range s {
+    for offset, char := range s {
         if !unicode.IsDigit(char) {
-            return nil, errors.New("input contains non-digit characters")
+            return nil, &InvalidCharError{Char: char, Offset: offset}
         }
         digit, _ := strconv.Atoi(string(char))
         digits = append(digits, digit)
@@ -66,16 +136,20 @@ func stringToDigits(s string) ([]int, error) {
 
 // intToDigits converts an integer to a slice of digits.
 func intToDigits(n int) []int {
+    return int64ToDigits(int64(n))
+}
+
+// int64ToDigits converts an int64 to a slice of the digits of its
+// magnitude. absInt64 is used because negating math.MinInt64 overflows.
+func int64ToDigits(n int64) []int {
     if n == 0 {
         return []int{0}
     }
     
-    if n < 0 {
-        n = -n // Handle negative numbers by taking absolute value
-    }
+    magnitude := absInt64(n)
     
     // Count digits
-    temp := n
+    temp := magnitude
     count := 0
     for temp > 0 {
         count++
@@ -85,39 +159,27 @@ func intToDigits(n int) []int {
     // Extract digits in reverse order then reverse
     digits := make([]int, count)
     for i := count - 1; i >= 0; i-- {
-        digits[i] = n % 10
-        n /= 10
+        digits[i] = int(magnitude % 10)
+        magnitude /= 10
     }
     
     return digits
 }
 
-// int64ToDigits converts an int64 to a slice of digits.
-func int64ToDigits(n int64) []int {
-    if n == 0 {
-        return []int{0}
+// floatToInt64 converts an integer-valued float64 to an int64, rejecting
+// fractional values and magnitudes of 2^53 or more where precision may
+// have been lost.
+func floatToInt64(f float64) (int64, error) {
+    if math.IsNaN(f) || math.IsInf(f, 0) {
+        return 0, errors.New("input is not a finite number")
     }
-    
-    if n < 0 {
-        n = -n // Handle negative numbers by taking absolute value
+    if f != math.Trunc(f) {
+        return 0, errors.New("input has a fractional part")
     }
-    
-    // Count digits
-    temp := n
-    count := 0
-    for temp > 0 {
-        count++
-        temp /= 10
+    if math.Abs(f) >= maxSafeFloat {
+        return 0, errors.New("input exceeds safe integer precision")
     }
-    
-    // Extract digits in reverse order then reverse
-    digits := make([]int, count)
-    for i := count - 1; i >= 0; i-- {
-        digits[i] = int(n % 10)
-        n /= 10
-    }
-    
-    return digits
+    return int64(f), nil
 }
 
 // sliceToDigits validates a slice of integers as digits.
@@ -125,13 +187,84 @@ func sliceToDigits(slice []int) ([]int, error) {
     result := make([]int, len(slice))
     for i, digit := range slice {
         if digit < 0 || digit > 9 {
-            return nil, errors.New("input contains invalid digit")
+            return nil, ErrInvalidDigit
         }
         result[i] = digit
     }
     return result, nil
 }
 
+// uint32SliceToDigits validates a slice of uint32 values as digits.
+func uint32SliceToDigits(slice []uint32) ([]int, error) {
+    result := make([]int, len(slice))
+    for i, digit := range slice {
+        if digit > 9 {
+            return nil, fmt.Errorf("%w %d at index %d", ErrInvalidDigit,
+                digit, i)
+        }
+        result[i] = int(digit)
+    }
+    return result, nil
+}
+
+// digitsToString formats a slice of digits as a string of ASCII digits.
+func digitsToString(digits []int) string {
+    buf := make([]byte, len(digits))
+    for i, digit := range digits {
+        buf[i] = byte('0' + digit)
+    }
+    return string(buf)
+}
+
+// absInt64 returns the magnitude of n as a uint64, which also covers
+// math.MinInt64.
+func absInt64(n int64) uint64 {
+    if n < 0 {
+        return -uint64(n)
+    }
+    return uint64(n)
+}
+
+// uint64Recurrence runs the Verhoeff recurrence over the decimal digits of n
+// and returns the final c. The digits are taken least significant first with
+// n % 10, which is the order the algorithm consumes them in, so no digit
+// slice is needed. offset is 1 when generating a check digit for n and 0
+// when n already ends in one.
+func uint64Recurrence(n uint64, offset int) int {
+    c := 0
+    i := offset
+    for {
+        c = d[c][p[i%8][n%10]]
+        n /= 10
+        i++
+        if n == 0 {
+            return c
+        }
+    }
+}
+
+// dpRowSize is the number of dp entries for one position.
+const dpRowSize = 100
+
+// dp fuses the d and p tables into one flat lookup for the checksum loops:
+// dp[pos*100+c*10+digit] holds d[c][p[pos][digit]] for pos 0-7, so each
+// digit costs a single array access instead of two nested slice lookups
+// and a modulo.
+var dp = buildDP()
+
+// buildDP computes dp from d and p.
+func buildDP() [8 * dpRowSize]uint8 {
+    var table [8 * dpRowSize]uint8
+    for pos := 0; pos < 8; pos++ {
+        for c := 0; c < 10; c++ {
+            for digit := 0; digit < 10; digit++ {
+                table[pos*dpRowSize+c*10+digit] = uint8(d[c][p[pos][digit]])
+            }
+        }
+    }
+    return table
+}
+
 // reverseDigits reverses a slice of digits in place.
 func reverseDigits(digits []int) {
     for i, j := 0, len(digits)-1; i < j; i, j = i+1, j-1 {
@@ -141,16 +274,23 @@ func reverseDigits(digits []int) {
 
 // calculateChecksum calculates the Verhoeff checksum for a slice of digits.
 func calculateChecksum(digits []int) int {
-    // Work with a copy to avoid modifying the input
-    reversed := make([]int, len(digits))
-    copy(reversed, digits)
-    reverseDigits(reversed)
-    
+    // Walk the digits from the right, so the rightmost one is at position 1
+    // without reversing a copy of the slice.
     c := 0
-    for i, digit := range reversed {
-        c = d[c][p[(i+1)%8][digit]]
+    row := dpRowSize // position 1
+    for i := len(digits) - 1; i >= 0; i-- {
+        c = int(dp[row+c*10+digits[i]])
+        row += dpRowSize
+        if row == len(dp) {
+            row = 0
+        }
     }
     
+    // Validation appends the check digit x at position 0, where p[0] is
+    // the identity, so it computes d[x][c] and accepts the number when that
+    // is 0. The only x that satisfies this is the group inverse of c, which
+    // is what inv holds. The lookup cannot be dropped; the alternative of
+    // trying all ten digits is strictly slower.
     return inv[c]
 }
 
@@ -160,14 +300,16 @@ func validateChecksum(digits []int) bool {
         return false
     }
     
-    // Work with a copy to avoid modifying the input
-    reversed := make([]int, len(digits))
-    copy(reversed, digits)
-    reverseDigits(reversed)
-    
+    // As in calculateChecksum, walk from the right instead of reversing,
+    // with the check digit at position 0.
     c := 0
-    for i, digit := range reversed {
-        c = d[c][p[i%8][digit]]
+    row := 0 // position 0
+    for i := len(digits) - 1; i >= 0; i-- {
+        c = int(dp[row+c*10+digits[i]])
+        row += dpRowSize
+        if row == len(dp) {
+            row = 0
+        }
     }
     
     return c == 0
@@ -175,6 +317,18 @@ func validateChecksum(digits []int) bool {
 
 // GenerateFromString calculates the Verhoeff checksum digit for a string of digits.
 func GenerateFromString(s string) (int, error) {
+    observe := ObserveLatency
+    if observe == nil {
+        return generateFromString(s)
+    }
+    start := time.Now()
+    checksum, err := generateFromString(s)
+    observe(time.Since(start))
+    return checksum, err
+}
+
+// generateFromString is GenerateFromString without latency reporting.
+func generateFromString(s string) (int, error) {
     digits, err := stringToDigits(s)
     if err != nil {
         return -1, err
@@ -183,15 +337,26 @@ func GenerateFromString(s string) (int, error) {
 }
 
 // GenerateInt calculates the Verhoeff checksum digit for an integer.
+// Negative values use the digits of their magnitude.
 func GenerateInt(n int) int {
-    digits := intToDigits(n)
-    return calculateChecksum(digits)
+    return inv[uint64Recurrence(absInt64(int64(n)), 1)]
 }
 
 // GenerateInt64 calculates the Verhoeff checksum digit for an int64.
+// Negative values use the digits of their magnitude, so math.MinInt64 is
+// treated as 9223372036854775808.
 func GenerateInt64(n int64) int {
-    digits := int64ToDigits(n)
-    return calculateChecksum(digits)
+    return inv[uint64Recurrence(absInt64(n), 1)]
+}
+
+// GenerateFromFloat calculates the Verhoeff checksum digit for an
+// integer-valued float64, such as a whole number decoded from JSON.
+func GenerateFromFloat(f float64) (int, error) {
+    n, err := floatToInt64(f)
+    if err != nil {
+        return -1, err
+    }
+    return GenerateInt64(n), nil
 }
 
 // GenerateSlice calculates the Verhoeff checksum digit for a slice of digits.
@@ -203,20 +368,83 @@ func GenerateSlice(digits []int) (int, error) {
     return calculateChecksum(validDigits), nil
 }
 
+// GenerateBytes calculates the Verhoeff checksum digit for a byte slice of
+// ASCII digits. It walks the bytes directly and does not allocate.
+func GenerateBytes(b []byte) (int, error) {
+    c := 0
+    for i := len(b) - 1; i >= 0; i-- {
+        digit := b[i] - '0'
+        if digit > 9 {
+            return -1, ErrNonDigit
+        }
+        c = d[c][p[(len(b)-i)%8][digit]]
+    }
+    return inv[c], nil
+}
+
+// GenerateFromStringByte calculates the Verhoeff checksum digit for a string
+// of digits and returns it as an ASCII byte ('0' to '9'), ready to write
+// into a buffer. It returns 0 on error.
+func GenerateFromStringByte(s string) (byte, error) {
+    checksum, err := GenerateFromString(s)
+    if err != nil {
+        return 0, err
+    }
+    return byte('0' + checksum), nil
+}
+
+// GenerateIntByte calculates the Verhoeff checksum digit for an integer and
+// returns it as an ASCII byte.
+func GenerateIntByte(n int) byte {
+    return byte('0' + GenerateInt(n))
+}
+
+// GenerateInt64Byte calculates the Verhoeff checksum digit for an int64 and
+// returns it as an ASCII byte.
+func GenerateInt64Byte(n int64) byte {
+    return byte('0' + GenerateInt64(n))
+}
+
+// GenerateUint32Slice calculates the Verhoeff checksum digit for a slice of
+// uint32 digits, as carried by repeated uint32 protobuf fields.
+func GenerateUint32Slice(digits []uint32) (int, error) {
+    validDigits, err := uint32SliceToDigits(digits)
+    if err != nil {
+        return -1, err
+    }
+    return calculateChecksum(validDigits), nil
+}
+
 // Generate calculates the Verhoeff checksum digit for various input types.
-// Supported types: string, int, int64, []int
+// Supported types: string, int, int64, *big.Int, []int, []uint32
 // This function is kept for backward compatibility but using the type-specific
 // functions (GenerateFromString, GenerateInt, etc.) is recommended.
 func Generate(input interface{}) (int, error) {
+    observe := ObserveLatency
+    if observe == nil {
+        return generate(input)
+    }
+    start := time.Now()
+    checksum, err := generate(input)
+    observe(time.Since(start))
+    return checksum, err
+}
+
+// generate is Generate without latency reporting.
+func generate(input interface{}) (int, error) {
     switch v := input.(type) {
     case string:
-        return GenerateFromString(v)
+        return generateFromString(v)
     case int:
         return GenerateInt(v), nil
     case int64:
         return GenerateInt64(v), nil
+    case *big.Int:
+        return GenerateBigInt(v)
     case []int:
         return GenerateSlice(v)
+    case []uint32:
+        return GenerateUint32Slice(v)
     default:
         return -1, fmt.Errorf("unsupported input type: %T", input)
     }
@@ -224,26 +452,109 @@ func Generate(input interface{}) (int, error) {
 
 // ValidateString checks if a string number with its checksum digit is valid.
 func ValidateString(s string) (bool, error) {
+    observe := ObserveLatency
+    if observe == nil {
+        return validateString(s)
+    }
+    start := time.Now()
+    valid, err := validateString(s)
+    observe(time.Since(start))
+    return valid, err
+}
+
+// validateString is ValidateString without latency reporting. ASCII digits
+// are checked byte by byte without building a digit slice; any other byte
+// falls back to stringToDigits, which also produces the error.
+func validateString(s string) (bool, error) {
+    c := 0
+    row := 0 // position 0
+    for i := len(s) - 1; i >= 0; i-- {
+        digit := s[i] - '0'
+        if digit > 9 {
+            return validateStringSlow(s)
+        }
+        c = int(dp[row+c*10+int(digit)])
+        row += dpRowSize
+        if row == len(dp) {
+            row = 0
+        }
+    }
+    if len(s) == 0 {
+        return false, ErrEmptyInput
+    }
+    return c == 0, nil
+}
+
+// validateStringSlow is validateString for input that is not all ASCII
+// digits.
+func validateStringSlow(s string) (bool, error) {
     digits, err := stringToDigits(s)
     if err != nil {
         return false, err
     }
     if len(digits) == 0 {
-        return false, errors.New("empty input")
+        return false, ErrEmptyInput
     }
     return validateChecksum(digits), nil
 }
 
+// Parse validates s and splits it into the payload and its trailing check
+// digit in one call. valid reports whether the check digit is correct; a
+// wrong check digit is not an error, so the payload and check are returned
+// either way. It returns ErrEmptyInput for an empty string and an error for
+// non-digit characters.
+func Parse(s string) (payload string, check int, valid bool, err error) {
+    digits, err := stringToDigits(s)
+    if err != nil {
+        return "", -1, false, err
+    }
+    if len(digits) == 0 {
+        return "", -1, false, ErrEmptyInput
+    }
+
+    payload = digitsToString(digits[:len(digits)-1])
+    check = digits[len(digits)-1]
+    return payload, check, validateChecksum(digits), nil
+}
+
 // ValidateInt checks if an integer with its checksum digit is valid.
+// Negative values use the digits of their magnitude.
 func ValidateInt(n int) bool {
-    digits := intToDigits(n)
-    return validateChecksum(digits)
+    return uint64Recurrence(absInt64(int64(n)), 0) == 0
 }
 
 // ValidateInt64 checks if an int64 with its checksum digit is valid.
+// Negative values use the digits of their magnitude, including
+// math.MinInt64.
 func ValidateInt64(n int64) bool {
-    digits := int64ToDigits(n)
-    return validateChecksum(digits)
+    return uint64Recurrence(absInt64(n), 0) == 0
+}
+
+// ValidateBytes checks if a byte slice of ASCII digits ending in its
+// checksum digit is valid. Like GenerateBytes, it does not allocate.
+func ValidateBytes(b []byte) (bool, error) {
+    if len(b) == 0 {
+        return false, ErrEmptyInput
+    }
+    c := 0
+    for i := len(b) - 1; i >= 0; i-- {
+        digit := b[i] - '0'
+        if digit > 9 {
+            return false, ErrNonDigit
+        }
+        c = d[c][p[(len(b)-1-i)%8][digit]]
+    }
+    return c == 0, nil
+}
+
+// ValidateFloat checks if an integer-valued float64 with its checksum digit
+// is valid.
+func ValidateFloat(f float64) (bool, error) {
+    n, err := floatToInt64(f)
+    if err != nil {
+        return false, err
+    }
+    return ValidateInt64(n), nil
 }
 
 // ValidateSlice checks if a slice of digits with its checksum is valid.
@@ -253,25 +564,92 @@ func ValidateSlice(digits []int) (bool, error) {
         return false, err
     }
     if len(validDigits) == 0 {
-        return false, errors.New("empty input")
+        return false, ErrEmptyInput
+    }
+    return validateChecksum(validDigits), nil
+}
+
+// CheckSlice reports whether check is the correct checksum digit for the
+// payload digits, without requiring the caller to append it to the slice.
+func CheckSlice(payload []int, check int) (bool, error) {
+    validDigits, err := sliceToDigits(payload)
+    if err != nil {
+        return false, err
+    }
+    if check < 0 || check > 9 {
+        return false, errors.New("check digit must be between 0 and 9")
+    }
+    return calculateChecksum(validDigits) == check, nil
+}
+
+// ValidateChecksumConstantTime reports whether expected is the checksum digit
+// for base, comparing the two with crypto/subtle so the accept/reject
+// decision does not branch on which digits differ. This is meant for check
+// digits used as a low-grade integrity token, where an attacker probing
+// with guesses should learn nothing from timing beyond the answer itself.
+//
+// Only the final comparison is constant time. Computing the checksum takes
+// time proportional to len(base), and an expected value outside 0-9 is
+// rejected up front with an error. The Verhoeff digit is not a secret in
+// any strong sense: it has ten possible values and anyone holding base can
+// compute it.
+func ValidateChecksumConstantTime(base string, expected int) (bool, error) {
+    if expected < 0 || expected > 9 {
+        return false, errors.New("check digit must be between 0 and 9")
+    }
+    checksum, err := GenerateFromString(base)
+    if err != nil {
+        return false, err
+    }
+    return subtle.ConstantTimeEq(int32(checksum), int32(expected)) == 1, nil
+}
+
+// ValidateUint32Slice checks if a slice of uint32 digits with its checksum
+// is valid.
+func ValidateUint32Slice(digits []uint32) (bool, error) {
+    validDigits, err := uint32SliceToDigits(digits)
+    if err != nil {
+        return false, err
+    }
+    if len(validDigits) == 0 {
+        return false, ErrEmptyInput
     }
     return validateChecksum(validDigits), nil
 }
 
 // Validate checks if a number with its checksum digit is valid.
-// Supported types: string, int, int64, []int
+// Supported types: string, int, int64, *big.Int, []int, []uint32
 // This function is kept for backward compatibility but using the type-specific
 // functions (ValidateString, ValidateInt, etc.) is recommended.
 func Validate(input interface{}) (bool, error) {
+    observe := ObserveLatency
+    if observe == nil {
+        return validate(input)
+    }
+    start := time.Now()
+    valid, err := validate(input)
+    observe(time.Since(start))
+    return valid, err
+}
+
+// validate is Validate without latency reporting.
+func validate(input interface{}) (bool, error) {
     switch v := input.(type) {
     case string:
-        return ValidateString(v)
+        return validateString(v)
     case int:
         return ValidateInt(v), nil
     case int64:
         return ValidateInt64(v), nil
+    case *big.Int:
+        if v == nil {
+            return false, errNilBigInt
+        }
+        return ValidateBigInt(v), nil
     case []int:
         return ValidateSlice(v)
+    case []uint32:
+        return ValidateUint32Slice(v)
     default:
         return false, fmt.Errorf("unsupported input type: %T", input)
     }
@@ -279,15 +657,17 @@ func Validate(input interface{}) (bool, error) {
 
 // ValidateAadhaar checks if an Aadhaar number (Indian identification
 // number) is valid. Aadhaar numbers must be exactly 12 digits, and the
-// last digit is a checksum.
+// last digit is a checksum. A wrong length is reported as ErrInvalidLength
+// and a non-digit character as an *InvalidCharError, both wrapped.
 func ValidateAadhaar(aadhaarStr string) (bool, error) {
     if len(aadhaarStr) != 12 {
-        return false, errors.New("aadhaar numbers should be 12 digits in length")
+        return false, fmt.Errorf("aadhaar numbers should be 12 digits in length: %w",
+            ErrInvalidLength)
     }
 
     digits, err := stringToDigits(aadhaarStr)
     if err != nil {
-        return false, errors.New("aadhaar numbers must contain only numbers")
+        return false, fmt.Errorf("aadhaar numbers must contain only numbers: %w", err)
     }
 
     // Extract the checksum digit (the last digit)
@@ -300,6 +680,9 @@ func ValidateAadhaar(aadhaarStr string) (bool, error) {
 }
 
 // AppendChecksumString adds the calculated checksum digit to a string.
+// The checksum of an empty string is 0, so AppendChecksumString("") returns
+// "0", which itself validates. Use AppendChecksumStringStrict where an empty
+// field should be rejected instead.
 func AppendChecksumString(s string) (string, error) {
     checksum, err := GenerateFromString(s)
     if err != nil {
@@ -308,6 +691,15 @@ func AppendChecksumString(s string) (string, error) {
     return s + strconv.Itoa(checksum), nil
 }
 
+// AppendChecksumStringStrict works like AppendChecksumString but returns
+// ErrEmptyInput for an empty string rather than "0".
+func AppendChecksumStringStrict(s string) (string, error) {
+    if s == "" {
+        return "", ErrEmptyInput
+    }
+    return AppendChecksumString(s)
+}
+
 // AppendChecksumInt adds the calculated checksum digit to an integer.
 func AppendChecksumInt(n int) string {
     checksum := GenerateInt(n)
@@ -320,6 +712,24 @@ func AppendChecksumInt64(n int64) string {
     return strconv.FormatInt(n, 10) + strconv.Itoa(checksum)
 }
 
+// AppendChecksumIntWidth zero-pads n to width digits, calculates the checksum
+// over the padded digits and appends it, giving a string of width+1 digits.
+// Unlike AppendChecksumInt, the leading zeros take part in the checksum.
+func AppendChecksumIntWidth(n int, width int) (string, error) {
+    if n < 0 {
+        return "", errors.New("negative numbers cannot be zero-padded")
+    }
+    if width <= 0 {
+        return "", errors.New("width must be positive")
+    }
+
+    padded := fmt.Sprintf("%0*d", width, n)
+    if len(padded) > width {
+        return "", fmt.Errorf("%d does not fit in %d digits", n, width)
+    }
+    return AppendChecksumString(padded)
+}
+
 // AppendChecksumSlice adds the calculated checksum digit to a slice of digits.
 func AppendChecksumSlice(digits []int) (string, error) {
     checksum, err := GenerateSlice(digits)
@@ -334,8 +744,36 @@ func AppendChecksumSlice(digits []int) (string, error) {
     return result + strconv.Itoa(checksum), nil
 }
 
+// AppendChecksumInto appends the ASCII digits in src followed by their
+// checksum digit to dst and returns the extended slice, in the style of
+// strconv.AppendInt. It does not allocate when dst has enough capacity,
+// which suits pooled buffers. On error dst is returned unchanged.
+func AppendChecksumInto(dst []byte, src []byte) ([]byte, error) {
+    checksum, err := GenerateBytes(src)
+    if err != nil {
+        return dst, err
+    }
+    dst = append(dst, src...)
+    return append(dst, byte('0'+checksum)), nil
+}
+
+// AppendChecksumUint32Slice adds the calculated checksum digit to a slice of
+// uint32 digits.
+func AppendChecksumUint32Slice(digits []uint32) (string, error) {
+    checksum, err := GenerateUint32Slice(digits)
+    if err != nil {
+        return "", err
+    }
+
+    result := ""
+    for _, d := range digits {
+        result += strconv.FormatUint(uint64(d), 10)
+    }
+    return result + strconv.Itoa(checksum), nil
+}
+
 // AppendChecksum adds the calculated checksum digit to the input.
-// Supported types: string, int, int64, []int
+// Supported types: string, int, int64, *big.Int, []int, []uint32
 // This function is kept for backward compatibility but using the type-specific
 // functions (AppendChecksumString, AppendChecksumInt, etc.) is recommended.
 func AppendChecksum(input interface{}) (string, error) {
@@ -346,15 +784,23 @@ func AppendChecksum(input interface{}) (string, error) {
         return AppendChecksumInt(v), nil
     case int64:
         return AppendChecksumInt64(v), nil
+    case *big.Int:
+        checksum, err := GenerateBigInt(v)
+        if err != nil {
+            return "", err
+        }
+        return v.String() + strconv.Itoa(checksum), nil
     case []int:
         return AppendChecksumSlice(v)
+    case []uint32:
+        return AppendChecksumUint32Slice(v)
     default:
         return "", fmt.Errorf("unsupported input type: %T", input)
     }
 }
 
 // ConvertToDigits converts various input types to a slice of digits.
-// Supported types: string, int, int64, []int
+// Supported types: string, int, int64, *big.Int, []int, []uint32
 // This function provides compatibility with the original API.
 func ConvertToDigits(input interface{}) ([]int, error) {
     switch v := input.(type) {
@@ -364,8 +810,12 @@ func ConvertToDigits(input interface{}) ([]int, error) {
         return intToDigits(v), nil
     case int64:
         return int64ToDigits(v), nil
+    case *big.Int:
+        return bigIntToDigits(v)
     case []int:
         return sliceToDigits(v)
+    case []uint32:
+        return uint32SliceToDigits(v)
     default:
         return nil, fmt.Errorf("unsupported input type: %T", input)
     }
@@ -3,8 +3,11 @@
 package verhoeff
 
 import (
+    "errors"
+    "math"
     "strconv"
     "testing"
+    "time"
 )
 
 func TestConvertToDigits(t *testing.T) {
@@ -17,6 +20,8 @@ func TestConvertToDigits(t *testing.T) {
         {"String input", "12345", []int{1, 2, 3, 4, 5}, false},
         {"Integer input", 12345, []int{1, 2, 3, 4, 5}, false},
         {"Int64 input", int64(12345), []int{1, 2, 3, 4, 5}, false},
+        {"Int64 minimum", int64(math.MinInt64),
+            []int{9, 2, 2, 3, 3, 7, 2, 0, 3, 6, 8, 5, 4, 7, 7, 5, 8, 0, 8}, false},
         {"Slice input", []int{1, 2, 3, 4, 5}, []int{1, 2, 3, 4, 5}, false},
         {"Empty string", "", []int{}, false},
         {"String with non-digits", "123a45", nil, true},
@@ -209,6 +214,229 @@ func TestValidate(t *testing.T) {
     }
 }
 
+func TestParse(t *testing.T) {
+    tests := []struct {
+        name            string
+        input           string
+        expectedPayload string
+        expectedCheck   int
+        expectedValid   bool
+        hasError        bool
+    }{
+        {"Valid number", "123451", "12345", 1, true, false},
+        {"Wrong check digit", "123459", "12345", 9, false, false},
+        {"Single digit", "0", "", 0, true, false},
+        {"Empty string", "", "", -1, false, true},
+        {"Non-digit", "12a451", "", -1, false, true},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            payload, check, valid, err := Parse(tt.input)
+
+            if (err != nil) != tt.hasError {
+                t.Errorf("Parse() error = %v, wantErr %v", err, tt.hasError)
+                return
+            }
+
+            if payload != tt.expectedPayload || check != tt.expectedCheck ||
+                valid != tt.expectedValid {
+                t.Errorf("Parse() = (%q, %d, %v), want (%q, %d, %v)",
+                    payload, check, valid,
+                    tt.expectedPayload, tt.expectedCheck, tt.expectedValid)
+            }
+        })
+    }
+}
+
+func TestCheckSlice(t *testing.T) {
+    tests := []struct {
+        name     string
+        payload  []int
+        check    int
+        expected bool
+        hasError bool
+    }{
+        {"Matching check digit", []int{2, 3, 6}, 3, true, false},
+        {"Wrong check digit", []int{2, 3, 6}, 4, false, false},
+        {"Longer payload", []int{1, 2, 3, 4, 5}, 1, true, false},
+        {"Empty payload", []int{}, 0, true, false},
+        {"Invalid payload digit", []int{1, 10, 3}, 0, false, true},
+        {"Check digit too large", []int{2, 3, 6}, 10, false, true},
+        {"Negative check digit", []int{2, 3, 6}, -1, false, true},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            got, err := CheckSlice(tt.payload, tt.check)
+
+            if (err != nil) != tt.hasError {
+                t.Errorf("CheckSlice() error = %v, wantErr %v",
+                    err, tt.hasError)
+                return
+            }
+
+            if got != tt.expected {
+                t.Errorf("CheckSlice() = %v, want %v", got, tt.expected)
+            }
+        })
+    }
+}
+
+func TestValidateChecksumConstantTime(t *testing.T) {
+    tests := []struct {
+        name     string
+        base     string
+        check    int
+        expected bool
+        hasError bool
+    }{
+        {"Matching check digit", "236", 3, true, false},
+        {"Wrong check digit", "236", 4, false, false},
+        {"Longer base", "12345", 1, true, false},
+        {"Empty base", "", 0, true, false},
+        {"Non-digit base", "12a", 0, false, true},
+        {"Check digit too large", "236", 13, false, true},
+        {"Negative check digit", "236", -7, false, true},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            got, err := ValidateChecksumConstantTime(tt.base, tt.check)
+
+            if (err != nil) != tt.hasError {
+                t.Errorf("ValidateChecksumConstantTime() error = %v, wantErr %v",
+                    err, tt.hasError)
+                return
+            }
+
+            if got != tt.expected {
+                t.Errorf("ValidateChecksumConstantTime() = %v, want %v",
+                    got, tt.expected)
+            }
+        })
+    }
+}
+
+func TestUint32Slice(t *testing.T) {
+    tests := []struct {
+        name          string
+        input         []uint32
+        expectedDigit int
+        expectedValid bool
+        hasError      bool
+    }{
+        {"Payload 1234", []uint32{1, 2, 3, 4}, 0, false, false},
+        {"Valid 12340", []uint32{1, 2, 3, 4, 0}, 5, true, false},
+        {"Out of range", []uint32{2, 3, 16}, -1, false, true},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            got, err := GenerateUint32Slice(tt.input)
+            if (err != nil) != tt.hasError {
+                t.Errorf("GenerateUint32Slice() error = %v, wantErr %v",
+                    err, tt.hasError)
+                return
+            }
+            if got != tt.expectedDigit {
+                t.Errorf("GenerateUint32Slice() = %v, want %v",
+                    got, tt.expectedDigit)
+            }
+
+            valid, err := ValidateUint32Slice(tt.input)
+            if (err != nil) != tt.hasError {
+                t.Errorf("ValidateUint32Slice() error = %v, wantErr %v",
+                    err, tt.hasError)
+                return
+            }
+            if valid != tt.expectedValid {
+                t.Errorf("ValidateUint32Slice() = %v, want %v",
+                    valid, tt.expectedValid)
+            }
+        })
+    }
+
+    t.Run("Dispatchers", func(t *testing.T) {
+        got, err := AppendChecksum([]uint32{2, 3, 6})
+        if err != nil || got != "2363" {
+            t.Errorf("AppendChecksum() = %v, %v, want 2363", got, err)
+        }
+        valid, err := Validate([]uint32{2, 3, 6, 3})
+        if err != nil || !valid {
+            t.Errorf("Validate() = %v, %v, want true", valid, err)
+        }
+        if _, err := ValidateUint32Slice([]uint32{}); err == nil {
+            t.Errorf("ValidateUint32Slice() expected error for empty input")
+        }
+    })
+}
+
+func TestGenerateBytes(t *testing.T) {
+    tests := []struct {
+        name          string
+        input         string
+        expectedDigit int
+        hasError      bool
+    }{
+        {"Bytes 236", "236", 3, false},
+        {"Bytes 12345", "12345", 1, false},
+        {"Bytes 84736430952", "84736430952", 5, false},
+        {"Empty bytes", "", 0, false},
+        {"Non-digit byte", "12a45", -1, true},
+        {"Byte below zero", "12/45", -1, true},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            got, err := GenerateBytes([]byte(tt.input))
+
+            if (err != nil) != tt.hasError {
+                t.Errorf("GenerateBytes() error = %v, wantErr %v",
+                    err, tt.hasError)
+                return
+            }
+
+            if got != tt.expectedDigit {
+                t.Errorf("GenerateBytes() = %v, want %v",
+                    got, tt.expectedDigit)
+            }
+        })
+    }
+}
+
+func TestValidateBytes(t *testing.T) {
+    tests := []struct {
+        name     string
+        input    string
+        expected bool
+        hasError bool
+    }{
+        {"Valid bytes", "2363", true, false},
+        {"Longer valid bytes", "123451", true, false},
+        {"Invalid bytes", "2364", false, false},
+        {"Empty bytes", "", false, true},
+        {"Non-digit byte", "12a451", false, true},
+        {"Byte below zero", "12/451", false, true},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            got, err := ValidateBytes([]byte(tt.input))
+
+            if (err != nil) != tt.hasError {
+                t.Errorf("ValidateBytes() error = %v, wantErr %v",
+                    err, tt.hasError)
+                return
+            }
+
+            if got != tt.expected {
+                t.Errorf("ValidateBytes() = %v, want %v", got, tt.expected)
+            }
+        })
+    }
+}
+
 func TestValidateAadhaar(t *testing.T) {
     tests := []struct {
         name     string
@@ -247,6 +475,55 @@ func TestValidateAadhaar(t *testing.T) {
     }
 }
 
+func TestErrorValues(t *testing.T) {
+    tests := []struct {
+        name       string
+        call       func() error
+        expected   error
+        charOffset int // byte offset of an *InvalidCharError, or -1
+    }{
+        {"Empty string", func() error { _, err := ValidateString(""); return err },
+            ErrEmptyInput, -1},
+        {"Non-digit", func() error { _, err := ValidateString("12a4"); return err },
+            ErrNonDigit, 2},
+        {"Non-digit after multi-byte digit", func() error { _, err := GenerateFromString("٢x"); return err },
+            ErrNonDigit, 2},
+        {"Invalid slice digit", func() error { _, err := GenerateSlice([]int{1, 10}); return err },
+            ErrInvalidDigit, -1},
+        {"Invalid uint32 digit", func() error { _, err := GenerateUint32Slice([]uint32{1, 10}); return err },
+            ErrInvalidDigit, -1},
+        {"Aadhaar wrong length", func() error { _, err := ValidateAadhaar("1234"); return err },
+            ErrInvalidLength, -1},
+        {"Aadhaar non-digit", func() error { _, err := ValidateAadhaar("12345678901a"); return err },
+            ErrNonDigit, 11},
+        {"Bytes non-digit", func() error { _, err := ValidateBytes([]byte("1a")); return err },
+            ErrNonDigit, -1},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            err := tt.call()
+            if !errors.Is(err, tt.expected) {
+                t.Fatalf("error = %v, want errors.Is %v", err, tt.expected)
+            }
+
+            var charErr *InvalidCharError
+            if errors.As(err, &charErr) != (tt.charOffset >= 0) {
+                t.Fatalf("errors.As(%v, *InvalidCharError) = %v, want %v",
+                    err, charErr != nil, tt.charOffset >= 0)
+            }
+            if charErr != nil && charErr.Offset != tt.charOffset {
+                t.Errorf("InvalidCharError.Offset = %d, want %d", charErr.Offset, tt.charOffset)
+            }
+        })
+    }
+
+    err := &InvalidCharError{Char: 'a', Offset: 2}
+    if got, want := err.Error(), "input contains non-digit characters: 'a' at offset 2"; got != want {
+        t.Errorf("InvalidCharError.Error() = %q, want %q", got, want)
+    }
+}
+
 func TestAppendChecksum(t *testing.T) {
     tests := []struct {
         name     string
@@ -296,6 +573,207 @@ func TestAppendChecksum(t *testing.T) {
     }
 }
 
+func TestAppendChecksumStringStrict(t *testing.T) {
+    tests := []struct {
+        name     string
+        input    string
+        expected string
+        hasError bool
+    }{
+        {"Valid string", "12345", "123451", false},
+        {"Single digit", "0", "04", false},
+        {"Empty string", "", "", true},
+        {"Invalid string", "12a34", "", true},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            got, err := AppendChecksumStringStrict(tt.input)
+
+            if (err != nil) != tt.hasError {
+                t.Errorf("AppendChecksumStringStrict() error = %v, wantErr %v",
+                    err, tt.hasError)
+                return
+            }
+
+            if got != tt.expected {
+                t.Errorf("AppendChecksumStringStrict() = %v, want %v",
+                    got, tt.expected)
+            }
+        })
+    }
+
+    t.Run("Empty reports ErrEmptyInput", func(t *testing.T) {
+        if _, err := AppendChecksumStringStrict(""); !errors.Is(err, ErrEmptyInput) {
+            t.Errorf("AppendChecksumStringStrict() error = %v, want ErrEmptyInput", err)
+        }
+    })
+
+    // The lenient variant keeps its documented behaviour: the empty string
+    // gets check digit 0, and "0" validates.
+    t.Run("Lenient empty round trip", func(t *testing.T) {
+        got, err := AppendChecksumString("")
+        if err != nil || got != "0" {
+            t.Fatalf("AppendChecksumString(\"\") = %q, %v, want \"0\", nil", got, err)
+        }
+        if valid, _ := ValidateString(got); !valid {
+            t.Errorf("ValidateString(%q) = false, want true", got)
+        }
+    })
+}
+
+func TestGenerateByte(t *testing.T) {
+    tests := []struct {
+        name     string
+        input    string
+        expected byte
+        hasError bool
+    }{
+        {"Valid string", "12345", '1', false},
+        {"Check digit zero", "123456789012", '0', false},
+        {"Empty string", "", '0', false},
+        {"Invalid string", "12a45", 0, true},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            got, err := GenerateFromStringByte(tt.input)
+
+            if (err != nil) != tt.hasError {
+                t.Errorf("GenerateFromStringByte() error = %v, wantErr %v",
+                    err, tt.hasError)
+                return
+            }
+
+            if got != tt.expected {
+                t.Errorf("GenerateFromStringByte() = %q, want %q",
+                    got, tt.expected)
+            }
+
+            if tt.hasError || tt.input == "" {
+                return
+            }
+
+            n, _ := strconv.ParseInt(tt.input, 10, 64)
+            if got := GenerateInt64Byte(n); got != tt.expected {
+                t.Errorf("GenerateInt64Byte() = %q, want %q", got, tt.expected)
+            }
+            if got := GenerateIntByte(int(n)); got != tt.expected {
+                t.Errorf("GenerateIntByte() = %q, want %q", got, tt.expected)
+            }
+        })
+    }
+}
+
+func TestAppendChecksumIntWidth(t *testing.T) {
+    tests := []struct {
+        name     string
+        input    int
+        width    int
+        expected string
+        hasError bool
+    }{
+        {"Padded", 12345, 8, "000123458", false},
+        {"Exact width", 12345, 5, "123451", false},
+        {"Zero", 0, 5, "000008", false},
+        {"Single digit", 7, 10, "00000000079", false},
+        {"Does not fit", 123456, 5, "", true},
+        {"Negative number", -12345, 8, "", true},
+        {"Zero width", 1, 0, "", true},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            got, err := AppendChecksumIntWidth(tt.input, tt.width)
+
+            if (err != nil) != tt.hasError {
+                t.Errorf("AppendChecksumIntWidth() error = %v, wantErr %v",
+                    err, tt.hasError)
+                return
+            }
+
+            if got != tt.expected {
+                t.Errorf("AppendChecksumIntWidth() = %v, want %v",
+                    got, tt.expected)
+            }
+        })
+    }
+}
+
+func TestFloatInput(t *testing.T) {
+    tests := []struct {
+        name          string
+        input         float64
+        expectedDigit int
+        expectedValid bool
+        hasError      bool
+    }{
+        {"Whole number", 12345.0, 1, false, false},
+        {"Valid number", 123451.0, 0, true, false},
+        {"Negative whole number", -12345.0, 1, false, false},
+        {"At 2^53", 9007199254740992, -1, false, true},
+        {"2^53+1 rounded to 2^53", 9007199254740993, -1, false, true},
+        {"Below 2^53", 9007199254740991, 9, false, false},
+        {"Above 2^53", 9007199254740994, -1, false, true},
+        {"Fractional part", 12345.5, -1, false, true},
+        {"NaN", math.NaN(), -1, false, true},
+        {"Infinity", math.Inf(1), -1, false, true},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            got, err := GenerateFromFloat(tt.input)
+            if (err != nil) != tt.hasError {
+                t.Errorf("GenerateFromFloat() error = %v, wantErr %v",
+                    err, tt.hasError)
+                return
+            }
+            if got != tt.expectedDigit {
+                t.Errorf("GenerateFromFloat() = %v, want %v",
+                    got, tt.expectedDigit)
+            }
+
+            valid, err := ValidateFloat(tt.input)
+            if (err != nil) != tt.hasError {
+                t.Errorf("ValidateFloat() error = %v, wantErr %v",
+                    err, tt.hasError)
+                return
+            }
+            if valid != tt.expectedValid {
+                t.Errorf("ValidateFloat() = %v, want %v",
+                    valid, tt.expectedValid)
+            }
+        })
+    }
+}
+
+func TestObserveLatency(t *testing.T) {
+    var calls int
+    ObserveLatency = func(d time.Duration) {
+        if d < 0 {
+            t.Errorf("ObserveLatency() got negative duration %v", d)
+        }
+        calls++
+    }
+    defer func() { ObserveLatency = nil }()
+
+    _, _ = GenerateFromString("12345")
+    _, _ = ValidateString("123451")
+    _, _ = Generate("12345")
+    _, _ = Validate(123451)
+    _, _ = ValidateString("12a")
+
+    if calls != 5 {
+        t.Errorf("ObserveLatency() called %d times, want 5", calls)
+    }
+
+    ObserveLatency = nil
+    _, _ = GenerateFromString("12345")
+    if calls != 5 {
+        t.Errorf("ObserveLatency() called after being unset")
+    }
+}
+
 // Benchmark tests
 func BenchmarkGenerate(b *testing.B) {
     for i := 0; i < b.N; i++ {
@@ -321,6 +799,46 @@ func BenchmarkAppendChecksum(b *testing.B) {
     }
 }
 
+// Allocation benchmarks comparing the type-specific generate functions
+func BenchmarkGenerateString(b *testing.B) {
+    b.ReportAllocs()
+    for i := 0; i < b.N; i++ {
+        _, _ = GenerateFromString("1234567890")
+    }
+}
+
+func BenchmarkGenerateBytes(b *testing.B) {
+    input := []byte("1234567890")
+    b.ReportAllocs()
+    for i := 0; i < b.N; i++ {
+        _, _ = GenerateBytes(input)
+    }
+}
+
+func BenchmarkGenerateSlice(b *testing.B) {
+    input := []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 0}
+    b.ReportAllocs()
+    for i := 0; i < b.N; i++ {
+        _, _ = GenerateSlice(input)
+    }
+}
+
+func BenchmarkGenerateInt(b *testing.B) {
+    b.ReportAllocs()
+    for i := 0; i < b.N; i++ {
+        _ = GenerateInt(1234567890)
+    }
+}
+
+// BenchmarkGenerateIntDigits is the digit-slice path GenerateInt used
+// before it ran the recurrence on the integer directly, as a baseline.
+func BenchmarkGenerateIntDigits(b *testing.B) {
+    b.ReportAllocs()
+    for i := 0; i < b.N; i++ {
+        _ = calculateChecksum(intToDigits(1234567890))
+    }
+}
+
 // Table-driven tests for edge cases
 func TestEdgeCases(t *testing.T) {
     t.Run("Large numbers", func(t *testing.T) {
@@ -367,4 +885,135 @@ func TestEdgeCases(t *testing.T) {
             t.Errorf("Sequential number validation failed")
         }
     })
-}
\ No newline at end of file
+}
+
+// TestInverseTable verifies inv holds the group inverse of each element of d,
+// which calculateChecksum relies on to pick the check digit.
+func TestInverseTable(t *testing.T) {
+    for x := 0; x < 10; x++ {
+        if d[x][inv[x]] != 0 || d[inv[x]][x] != 0 {
+            t.Errorf("inv[%d] = %d is not the inverse of %d", x, inv[x], x)
+        }
+    }
+}
+
+// generateByTrial finds the check digit by validating every candidate, the
+// inverse-free approach calculateChecksum deliberately avoids.
+func generateByTrial(digits []int) int {
+    candidate := make([]int, len(digits)+1)
+    copy(candidate, digits)
+    for x := 0; x < 10; x++ {
+        candidate[len(digits)] = x
+        if validateChecksum(candidate) {
+            return x
+        }
+    }
+    return -1
+}
+
+func TestGenerateByTrialMatches(t *testing.T) {
+    for n := 0; n < 2000; n++ {
+        digits := intToDigits(n)
+        if got, want := generateByTrial(digits), calculateChecksum(digits); got != want {
+            t.Errorf("generateByTrial(%d) = %d, want %d", n, got, want)
+        }
+    }
+}
+
+func BenchmarkGenerateWithInverse(b *testing.B) {
+    digits := []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 0}
+    b.ReportAllocs()
+    for i := 0; i < b.N; i++ {
+        _ = calculateChecksum(digits)
+    }
+}
+
+func BenchmarkGenerateByTrial(b *testing.B) {
+    digits := []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 0}
+    b.ReportAllocs()
+    for i := 0; i < b.N; i++ {
+        _ = generateByTrial(digits)
+    }
+}
+
+// TestIntegerPathMatchesString checks the allocation-free integer path
+// against the string implementation.
+func TestIntegerPathMatchesString(t *testing.T) {
+    values := []int64{0, 1, 9, 10, 236, 2363, 12345, 99999999,
+        math.MaxInt64, -12345, math.MinInt64}
+    for n := int64(0); n < 5000; n += 7 {
+        values = append(values, n)
+    }
+
+    for _, n := range values {
+        magnitude := strconv.FormatUint(absInt64(n), 10)
+        want, _ := GenerateFromString(magnitude)
+        if got := GenerateInt64(n); got != want {
+            t.Errorf("GenerateInt64(%d) = %d, want %d", n, got, want)
+        }
+        wantValid, _ := ValidateString(magnitude)
+        if got := ValidateInt64(n); got != wantValid {
+            t.Errorf("ValidateInt64(%d) = %v, want %v", n, got, wantValid)
+        }
+        if n >= math.MinInt32 && n <= math.MaxInt32 {
+            if got := GenerateInt(int(n)); got != want {
+                t.Errorf("GenerateInt(%d) = %d, want %d", n, got, want)
+            }
+            if got := ValidateInt(int(n)); got != wantValid {
+                t.Errorf("ValidateInt(%d) = %v, want %v", n, got, wantValid)
+            }
+        }
+    }
+}
+
+func TestAppendChecksumInto(t *testing.T) {
+    tests := []struct {
+        name     string
+        dst      string
+        src      string
+        expected string
+        hasError bool
+    }{
+        {"Empty dst", "", "236", "2363", false},
+        {"Existing dst", "id=", "12345", "id=123451", false},
+        {"Empty src", "x", "", "x0", false},
+        {"Non-digit src", "id=", "12a45", "id=", true},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            got, err := AppendChecksumInto([]byte(tt.dst), []byte(tt.src))
+
+            if (err != nil) != tt.hasError {
+                t.Errorf("AppendChecksumInto() error = %v, wantErr %v",
+                    err, tt.hasError)
+                return
+            }
+
+            if string(got) != tt.expected {
+                t.Errorf("AppendChecksumInto() = %q, want %q",
+                    got, tt.expected)
+            }
+        })
+    }
+
+    t.Run("Reuses capacity", func(t *testing.T) {
+        src := []byte("1234567890")
+        dst := make([]byte, 0, 64)
+        allocs := testing.AllocsPerRun(100, func() {
+            dst, _ = AppendChecksumInto(dst[:0], src)
+        })
+        if allocs != 0 {
+            t.Errorf("AppendChecksumInto() allocated %v times, want 0", allocs)
+        }
+    })
+}
+
+func BenchmarkAppendChecksumInto(b *testing.B) {
+    src := []byte("1234567890")
+    dst := make([]byte, 0, 64)
+    b.ReportAllocs()
+    for i := 0; i < b.N; i++ {
+        dst, _ = AppendChecksumInto(dst[:0], src)
+    }
+}